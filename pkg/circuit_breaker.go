@@ -0,0 +1,251 @@
+package postgrest_go
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through while counting failures.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails requests immediately.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through to decide whether to close again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// ErrCircuitOpen is matched by errors.Is when a request was rejected by an open circuit breaker.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned for requests rejected by an open circuit breaker without being sent.
+type CircuitOpenError struct {
+	// RetryAfter is the time left until the breaker lets probe requests through.
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open, retry after %s", e.RetryAfter)
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreakerSettings configures the circuit breaker of a PostgrestTransport. Network errors and 5xx
+// responses count as failures.
+type CircuitBreakerSettings struct {
+	// ConsecutiveFailures trips the breaker after this many failures in a row. Zero disables the check.
+	ConsecutiveFailures int
+	// FailureRate trips the breaker when the ratio of failed requests within Window reaches it.
+	// Zero disables the check.
+	FailureRate float64
+	// MinRequests is the number of requests within Window required before FailureRate applies.
+	MinRequests int
+	// Window is the interval over which the failure rate is computed. It defaults to 10s.
+	Window time.Duration
+	// OpenTimeout is how long the breaker stays open before probing. It defaults to 30s.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of successful probes required to close the breaker. It defaults to 1.
+	HalfOpenRequests int
+	// OnStateChange is called whenever the breaker changes state. It is called without holding the
+	// breaker's lock, so it may inspect the state, but it must not block.
+	OnStateChange func(from, to CircuitState)
+}
+
+// WithCircuitBreaker makes the transport fail fast with a CircuitOpenError while the server keeps failing.
+func WithCircuitBreaker(settings CircuitBreakerSettings) ClientOption {
+	return func(c *Client) {
+		c.Transport.breaker = newCircuitBreaker(settings)
+	}
+}
+
+type circuitBreaker struct {
+	settings CircuitBreakerSettings
+
+	mu                  sync.Mutex
+	state               CircuitState
+	generation          uint64
+	openedAt            time.Time
+	windowStart         time.Time
+	requests            int
+	failures            int
+	consecutiveFailures int
+	probes              int
+	probeSuccesses      int
+	// changes holds the state changes to report once the lock is released.
+	changes []stateChange
+
+	now func() time.Time
+}
+
+func newCircuitBreaker(settings CircuitBreakerSettings) *circuitBreaker {
+	if settings.Window <= 0 {
+		settings.Window = 10 * time.Second
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = 30 * time.Second
+	}
+	if settings.HalfOpenRequests <= 0 {
+		settings.HalfOpenRequests = 1
+	}
+	return &circuitBreaker{settings: settings, now: time.Now}
+}
+
+// State returns the current state of the breaker.
+func (cb *circuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.unlock()
+
+	cb.refresh()
+	return cb.state
+}
+
+// allow reports whether a request may be sent and returns the generation to report its result with.
+func (cb *circuitBreaker) allow() (uint64, error) {
+	cb.mu.Lock()
+	defer cb.unlock()
+
+	cb.refresh()
+	switch cb.state {
+	case CircuitOpen:
+		return 0, &CircuitOpenError{RetryAfter: cb.openedAt.Add(cb.settings.OpenTimeout).Sub(cb.now())}
+	case CircuitHalfOpen:
+		if cb.probes >= cb.settings.HalfOpenRequests {
+			return 0, &CircuitOpenError{}
+		}
+		cb.probes++
+	}
+	return cb.generation, nil
+}
+
+// requestOutcome is the result of a request as counted by the circuit breaker.
+type requestOutcome int
+
+const (
+	outcomeSuccess requestOutcome = iota
+	outcomeFailure
+	// outcomeIgnored is the outcome of requests that say nothing about the server, such as canceled ones.
+	outcomeIgnored
+)
+
+// done records the outcome of a request allowed in generation.
+func (cb *circuitBreaker) done(generation uint64, outcome requestOutcome) {
+	cb.mu.Lock()
+	defer cb.unlock()
+
+	// Results of requests sent before the last state change are stale.
+	if generation != cb.generation {
+		return
+	}
+
+	switch cb.state {
+	case CircuitHalfOpen:
+		switch outcome {
+		case outcomeIgnored:
+			// Let another probe take the place of this one.
+			cb.probes--
+			return
+		case outcomeFailure:
+			cb.setState(CircuitOpen)
+			return
+		}
+		cb.probeSuccesses++
+		if cb.probeSuccesses >= cb.settings.HalfOpenRequests {
+			cb.setState(CircuitClosed)
+		}
+	case CircuitClosed:
+		if outcome == outcomeIgnored {
+			return
+		}
+
+		now := cb.now()
+		if now.Sub(cb.windowStart) >= cb.settings.Window {
+			cb.windowStart, cb.requests, cb.failures = now, 0, 0
+		}
+
+		cb.requests++
+		if outcome == outcomeSuccess {
+			cb.consecutiveFailures = 0
+			return
+		}
+		cb.failures++
+		cb.consecutiveFailures++
+
+		s := cb.settings
+		if (s.ConsecutiveFailures > 0 && cb.consecutiveFailures >= s.ConsecutiveFailures) ||
+			(s.FailureRate > 0 && cb.requests >= s.MinRequests && float64(cb.failures)/float64(cb.requests) >= s.FailureRate) {
+			cb.setState(CircuitOpen)
+		}
+	}
+}
+
+// refresh moves an open breaker to half-open once its timeout elapsed.
+func (cb *circuitBreaker) refresh() {
+	if cb.state == CircuitOpen && cb.now().Sub(cb.openedAt) >= cb.settings.OpenTimeout {
+		cb.setState(CircuitHalfOpen)
+	}
+}
+
+func (cb *circuitBreaker) setState(state CircuitState) {
+	from := cb.state
+	cb.state = state
+	cb.generation++
+	cb.requests, cb.failures, cb.consecutiveFailures = 0, 0, 0
+	cb.probes, cb.probeSuccesses = 0, 0
+	cb.windowStart = cb.now()
+	if state == CircuitOpen {
+		cb.openedAt = cb.now()
+	}
+
+	if cb.settings.OnStateChange != nil {
+		cb.changes = append(cb.changes, stateChange{from: from, to: state})
+	}
+}
+
+type stateChange struct {
+	from, to CircuitState
+}
+
+// unlock releases the lock, then reports the state changes made while holding it.
+func (cb *circuitBreaker) unlock() {
+	changes := cb.changes
+	cb.changes = nil
+	cb.mu.Unlock()
+
+	for _, change := range changes {
+		cb.settings.OnStateChange(change.from, change.to)
+	}
+}
+
+// outcome classifies the result of a request. Network errors and 5xx responses are failures, while requests
+// canceled by their context are ignored.
+func outcome(req *http.Request, resp *http.Response, err error) requestOutcome {
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		return outcomeIgnored
+	case err != nil || resp.StatusCode >= 500:
+		return outcomeFailure
+	default:
+		return outcomeSuccess
+	}
+}
@@ -0,0 +1,208 @@
+package postgrest_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_ConsecutiveFailures(t *testing.T) {
+	failing := true
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}, WithCircuitBreaker(CircuitBreakerSettings{ConsecutiveFailures: 2}))
+
+	now := time.Now()
+	client.Transport.breaker.now = func() time.Time { return now }
+
+	var transitions []string
+	client.Transport.breaker.settings.OnStateChange = func(from, to CircuitState) {
+		transitions = append(transitions, from.String()+"->"+to.String())
+	}
+
+	var rows []map[string]interface{}
+	for i := 0; i < 2; i++ {
+		client.From("users").Select("*").Execute(&rows)
+	}
+	if got := client.Transport.CircuitState(); got != CircuitOpen {
+		t.Fatalf("expected breaker to be open, got %s", got)
+	}
+
+	err := client.From("users").Select("*").Execute(&rows)
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected *CircuitOpenError, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected the open breaker not to send requests, got %d attempts", attempts)
+	}
+
+	// After the timeout a successful probe closes the breaker.
+	now = now.Add(31 * time.Second)
+	failing = false
+	if err := client.From("users").Select("*").Execute(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if got := client.Transport.CircuitState(); got != CircuitClosed {
+		t.Errorf("expected breaker to be closed, got %s", got)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("expected transitions %v, got %v", want, transitions)
+			break
+		}
+	}
+}
+
+func TestCircuitBreaker_FailureRate(t *testing.T) {
+	cb := newCircuitBreaker(CircuitBreakerSettings{FailureRate: 0.5, MinRequests: 4})
+
+	for _, outcome := range []requestOutcome{outcomeSuccess, outcomeFailure, outcomeSuccess} {
+		generation, err := cb.allow()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cb.done(generation, outcome)
+	}
+	if cb.State() != CircuitClosed {
+		t.Fatal("expected breaker to stay closed below MinRequests")
+	}
+
+	generation, _ := cb.allow()
+	cb.done(generation, outcomeFailure)
+	if cb.State() != CircuitOpen {
+		t.Error("expected breaker to open at a 50% failure rate")
+	}
+}
+
+func TestCircuitBreaker_HalfOpenFailure(t *testing.T) {
+	cb := newCircuitBreaker(CircuitBreakerSettings{ConsecutiveFailures: 1, OpenTimeout: time.Second})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+
+	generation, _ := cb.allow()
+	cb.done(generation, outcomeFailure)
+
+	now = now.Add(time.Second)
+	probe, err := cb.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cb.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected a single probe while half-open, got %v", err)
+	}
+
+	cb.done(probe, outcomeFailure)
+	if cb.State() != CircuitOpen {
+		t.Error("expected a failed probe to reopen the breaker")
+	}
+}
+
+func TestCircuitBreaker_IgnoredOutcome(t *testing.T) {
+	cb := newCircuitBreaker(CircuitBreakerSettings{ConsecutiveFailures: 2})
+
+	for _, outcome := range []requestOutcome{outcomeFailure, outcomeIgnored, outcomeFailure} {
+		generation, _ := cb.allow()
+		cb.done(generation, outcome)
+	}
+	if cb.State() != CircuitOpen {
+		t.Error("expected an ignored request not to reset consecutive failures")
+	}
+
+	cb = newCircuitBreaker(CircuitBreakerSettings{FailureRate: 0.5, MinRequests: 2})
+	for _, outcome := range []requestOutcome{outcomeIgnored, outcomeIgnored, outcomeSuccess, outcomeFailure} {
+		generation, _ := cb.allow()
+		cb.done(generation, outcome)
+	}
+	if cb.State() != CircuitOpen {
+		t.Error("expected ignored requests not to count towards the failure rate")
+	}
+}
+
+func TestCircuitBreaker_HalfOpenIgnored(t *testing.T) {
+	cb := newCircuitBreaker(CircuitBreakerSettings{ConsecutiveFailures: 1, OpenTimeout: time.Second})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+
+	generation, _ := cb.allow()
+	cb.done(generation, outcomeFailure)
+
+	now = now.Add(time.Second)
+	probe, err := cb.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb.done(probe, outcomeIgnored)
+	if cb.State() != CircuitHalfOpen {
+		t.Fatalf("expected a canceled probe to leave the breaker half-open, got %s", cb.State())
+	}
+
+	probe, err = cb.allow()
+	if err != nil {
+		t.Fatalf("expected the probe slot to be released, got %v", err)
+	}
+	cb.done(probe, outcomeSuccess)
+	if cb.State() != CircuitClosed {
+		t.Error("expected a successful probe to close the breaker")
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+
+	if got := outcome(canceled, nil, context.Canceled); got != outcomeIgnored {
+		t.Errorf("expected canceled request to be ignored, got %d", got)
+	}
+	if got := outcome(req, nil, errors.New("connection reset")); got != outcomeFailure {
+		t.Errorf("expected network error to be a failure, got %d", got)
+	}
+	if got := outcome(req, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil); got != outcomeFailure {
+		t.Errorf("expected 503 to be a failure, got %d", got)
+	}
+	if got := outcome(req, &http.Response{StatusCode: http.StatusNotFound}, nil); got != outcomeSuccess {
+		t.Errorf("expected 404 to be a success, got %d", got)
+	}
+}
+
+func TestCircuitBreaker_OnStateChangeReadsState(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}, WithCircuitBreaker(CircuitBreakerSettings{ConsecutiveFailures: 1}))
+
+	var states []CircuitState
+	client.Transport.breaker.settings.OnStateChange = func(from, to CircuitState) {
+		states = append(states, client.Transport.CircuitState())
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var rows []map[string]interface{}
+		client.From("users").Select("*").Execute(&rows)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the state change callback not to deadlock")
+	}
+	if len(states) != 1 || states[0] != CircuitOpen {
+		t.Errorf("expected the callback to observe the open state, got %v", states)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 type Client struct {
@@ -16,6 +17,12 @@ type Client struct {
 	Debug          bool
 	defaultHeaders http.Header
 	Transport      *PostgrestTransport
+	costBudget     CostBudget
+	retryPolicy    RetryPolicy
+	metrics        MetricsObserver
+
+	deadlineTimeout       bool
+	deadlineTimeoutMargin time.Duration
 }
 
 type ClientOption func(c *Client)
@@ -32,8 +39,8 @@ func NewClient(baseURL url.URL, opts ...ClientOption) *Client {
 		session:        http.Client{Transport: &transport},
 	}
 
-	c.defaultHeaders.Set("Accept", "application/json")
-	c.defaultHeaders.Set("Content-Type", "application/json")
+	c.defaultHeaders.Set("Accept", MediaTypeJSON)
+	c.defaultHeaders.Set("Content-Type", MediaTypeJSON)
 	c.defaultHeaders.Set("Accept-Profile", "public")
 	c.defaultHeaders.Set("Content-Profile", "public")
 
@@ -41,9 +48,9 @@ func NewClient(baseURL url.URL, opts ...ClientOption) *Client {
 		opt(&c)
 	}
 
-	if c.Debug {
-		fmt.Println("CAUTION! Please make sure to disable the debug option before deploying it to production.")
-		c.Transport.debug = c.Debug
+	if c.Debug && c.Transport.logger == nil {
+		c.Transport.logger = debugLogger()
+		c.Transport.logger.logger.Warn("CAUTION! Please make sure to disable the debug option before deploying it to production.")
 	}
 	return &c
 }
@@ -62,7 +69,8 @@ type RpcRequestBuilder struct {
 	path       string
 	header     http.Header
 	httpMethod string
-	params     map[string]interface{}
+	params     interface{}
+	stripNulls bool
 }
 
 func (c *Client) Rpc(f string, params map[string]interface{}) *RpcRequestBuilder {
@@ -80,16 +88,78 @@ func (r *RpcRequestBuilder) Execute(result interface{}) error {
 }
 
 func (r *RpcRequestBuilder) ExecuteWithContext(ctx context.Context, result interface{}) error {
-	data, err := json.Marshal(r.params)
+	req, err := r.request(ctx)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, r.httpMethod, r.path, bytes.NewBuffer(data))
+	resp, err := r.client.do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return err
 	}
 
+	if resp.StatusCode != http.StatusNoContent && r != nil {
+		if err = json.Unmarshal(body, result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExecuteRaw calls the function and returns the undecoded response.
+// The caller is responsible for closing the response body.
+func (r *RpcRequestBuilder) ExecuteRaw() (*RawResponse, error) {
+	return r.ExecuteRawWithContext(context.Background())
+}
+
+// ExecuteRawWithContext calls the function with the provided context and returns the undecoded response.
+// The caller is responsible for closing the response body.
+func (r *RpcRequestBuilder) ExecuteRawWithContext(ctx context.Context) (*RawResponse, error) {
+	req, err := r.request(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return newRawResponse(resp), nil
+}
+
+// Accept sets the media type the function result should be returned in, e.g. MediaTypeOctetStream.
+// Responses in a media type other than JSON should be consumed through ExecuteRaw.
+func (r *RpcRequestBuilder) Accept(mediaType string) *RpcRequestBuilder {
+	r.header.Set("Accept", mediaType)
+	return r
+}
+
+// StripNulls omits null valued fields from the returned objects to shrink the response.
+// It requires PostgREST 11.2 or newer.
+func (r *RpcRequestBuilder) StripNulls() *RpcRequestBuilder {
+	r.stripNulls = true
+	return r
+}
+
+// request creates the HTTP request for the function call.
+func (r *RpcRequestBuilder) request(ctx context.Context) (*http.Request, error) {
+	data, err := json.Marshal(r.params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.httpMethod, r.path, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+
 	req.Header = r.client.Headers()
 
 	// inject/override custom headers
@@ -99,38 +169,78 @@ func (r *RpcRequestBuilder) ExecuteWithContext(ctx context.Context, result inter
 		}
 	}
 
+	if r.stripNulls {
+		req.Header.Set("Accept", withNullsStripped(req.Header.Get("Accept")))
+	}
+
 	req.URL.Path = req.URL.Path[1:]
 	req.URL = r.client.Transport.baseURL.ResolveReference(req.URL)
+	return req, nil
+}
 
-	resp, err := r.client.session.Do(req)
-	if err != nil {
-		return err
+// do sends the request, retrying it as allowed by the retry policy, and returns the response when the
+// server replied with a 2xx status. Any other response is decoded into a RequestError and its body is closed.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	statementTimeout := c.setStatementTimeout(req)
+
+	if c.metrics == nil {
+		resp, err := c.doWithRetries(req)
+		return resp, timeoutError(err, statementTimeout)
 	}
 
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
+	op := c.Transport.operation(req)
+	c.metrics.RequestStarted(op)
+	start := time.Now()
+
+	resp, err := c.doWithRetries(req)
+	c.metrics.RequestFinished(op, time.Since(start), err)
+
+	if observer, ok := c.metrics.(ServerTimingObserver); ok {
+		if header := responseHeader(resp, err).Get("Server-Timing"); header != "" {
+			observer.ObserveServerTiming(op, ParseServerTiming(header))
+		}
 	}
+	return resp, timeoutError(err, statementTimeout)
+}
 
-	statusOK := resp.StatusCode >= 200 && resp.StatusCode < 300
-	if !statusOK {
-		reqError := RequestError{HTTPStatusCode: resp.StatusCode}
+// doWithRetries sends the request, retrying it as allowed by the retry policy. See do.
+func (c *Client) doWithRetries(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(req)
+		wait, retry := c.retryPolicy.shouldRetry(req, attempt, err)
+		if !retry {
+			return resp, err
+		}
 
-		if err = json.Unmarshal(body, &reqError); err != nil {
-			return err
+		next, retryErr := retryRequest(req, wait)
+		if retryErr != nil {
+			// Keep the error of the last attempt along with the reason the retry was abandoned.
+			return nil, fmt.Errorf("%w (retry abandoned: %w)", err, retryErr)
 		}
+		req = next
+		c.setStatementTimeout(req)
+	}
+}
 
-		return &reqError
+// send sends the request once. See do.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.session.Do(req)
+	if err != nil {
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusNoContent && r != nil {
-		if err = json.Unmarshal(body, result); err != nil {
-			return err
-		}
+	statusOK := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if statusOK {
+		return resp, nil
 	}
 
-	return nil
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, newRequestError(req, resp, body)
 }
 
 func (c *Client) CloseIdleConnections() {
@@ -1,10 +1,27 @@
 package postgrest_go
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 )
 
+// newTestClient returns a client pointed at a local server serving handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(*baseURL, opts...)
+}
+
 func TestPostgrestClient_Constructor(t *testing.T) {
 	client := NewClient(url.URL{Scheme: "https", Host: "example.com"})
 
@@ -59,3 +76,50 @@ func TestPostgrestClient_Schema(t *testing.T) {
 		t.Errorf("expected header Content-Profile == %s, got %s", "private", got)
 	}
 }
+
+func TestRpcRequestBuilder_ExecuteRaw(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rpc/get_image" {
+			t.Errorf("expected path == %s, got %s", "/rpc/get_image", r.URL.Path)
+		}
+		if got := r.Header.Get("Accept"); got != MediaTypeOctetStream {
+			t.Errorf("expected header Accept == %s, got %s", MediaTypeOctetStream, got)
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte{0x89, 'P', 'N', 'G'})
+	})
+
+	resp, err := client.Rpc("get_image", map[string]interface{}{"id": 1}).
+		Accept(MediaTypeOctetStream).
+		ExecuteRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Close()
+
+	if resp.ContentType != "image/png" {
+		t.Errorf("expected ContentType == %s, got %s", "image/png", resp.ContentType)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "\x89PNG" {
+		t.Errorf("unexpected body %q", body)
+	}
+}
+
+func TestRpcRequestBuilder_StripNulls(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/vnd.pgrst.array+json;nulls=stripped" {
+			t.Errorf("unexpected header Accept %s", got)
+		}
+		w.Write([]byte(`[{"id":1}]`))
+	})
+
+	var rows []map[string]interface{}
+	if err := client.Rpc("list_items", nil).StripNulls().Execute(&rows); err != nil {
+		t.Fatal(err)
+	}
+}
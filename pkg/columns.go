@@ -0,0 +1,218 @@
+package postgrest_go
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SelectColumns returns the select list matching the fields of the struct v, which may also be a pointer
+// to a struct or a slice of structs.
+//
+// Columns are named after the postgrest struct tag, falling back to the json tag and then the field name.
+// A column differing from the json name of its field is aliased to that name, so the response decodes into v.
+// Fields of struct or slice of struct type are selected as embedded resources with their own columns.
+// The postgrest tag accepts the following options after the name:
+//
+//	column=name  select column name under the field's json name (alias:name)
+//	table=name   embed the resource name under the field's json name (alias:name(...))
+//	hint=fk      disambiguate the embedded resource with a foreign key or column hint (name!fk(...))
+//	inner        embed with an inner join (name!inner(...))
+//	scalar       select a struct typed field as a plain column, e.g. a json column
+//
+// A postgrest or json tag of "-" skips the field.
+func SelectColumns(v interface{}) (string, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return "", fmt.Errorf("cannot derive columns from nil")
+	}
+
+	columns, err := structColumns(elemStructType(t), map[reflect.Type]bool{})
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(columns, ","), nil
+}
+
+// SelectStruct starts building a SELECT request whose columns are derived from the struct v as by SelectColumns.
+func (b *RequestBuilder) SelectStruct(v interface{}) *SelectRequestBuilder {
+	columns, err := SelectColumns(v)
+	s := b.Select(columns)
+	if err != nil {
+		s.err = err
+	}
+	return s
+}
+
+// SelectFields starts building a SELECT request whose columns are derived from T as by SelectColumns.
+func (t *TypedTable[T]) SelectFields() *TypedSelect[T] {
+	var v T
+	return &TypedSelect[T]{t.RequestBuilder.SelectStruct(v)}
+}
+
+// fieldTag is the parsed column mapping of a struct field.
+type fieldTag struct {
+	// key is the name the field is decoded from by encoding/json.
+	key    string
+	name   string
+	column string
+	table  string
+	hint   string
+	inner  bool
+	scalar bool
+	skip   bool
+	op     string
+	pk     bool
+}
+
+func parseFieldTag(field reflect.StructField) fieldTag {
+	tag := fieldTag{key: field.Name, name: field.Name}
+
+	if jsonTag, ok := field.Tag.Lookup("json"); ok {
+		name, _, _ := strings.Cut(jsonTag, ",")
+		if name == "-" {
+			tag.skip = true
+		} else if name != "" {
+			tag.key = name
+			tag.name = name
+		}
+	}
+
+	pgTag, ok := field.Tag.Lookup("postgrest")
+	if !ok {
+		return tag
+	}
+
+	parts := strings.Split(pgTag, ",")
+	if parts[0] == "-" {
+		tag.skip = true
+		return tag
+	}
+	if parts[0] != "" {
+		tag.name = parts[0]
+		tag.skip = false
+	}
+
+	for _, opt := range parts[1:] {
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case "column":
+			tag.column = value
+		case "table":
+			tag.table = value
+		case "hint":
+			tag.hint = value
+		case "inner":
+			tag.inner = true
+		case "scalar":
+			tag.scalar = true
+		case "op":
+			tag.op = value
+		case "pk":
+			tag.pk = true
+		}
+	}
+	return tag
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// elemStructType dereferences pointers and slices down to the struct type, if any.
+func elemStructType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isEmbeddable reports whether fields of type t should be selected as embedded resources.
+func isEmbeddable(t reflect.Type) bool {
+	t = elemStructType(t)
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	// Types decoding themselves, such as time.Time, are plain columns.
+	ptr := reflect.PointerTo(t)
+	return !ptr.Implements(jsonUnmarshalerType) && !ptr.Implements(textUnmarshalerType)
+}
+
+func structColumns(t reflect.Type, visiting map[reflect.Type]bool) ([]string, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot derive columns from %s, expected a struct", t)
+	}
+	if visiting[t] {
+		return nil, fmt.Errorf("cannot derive columns from recursive type %s", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var columns []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := parseFieldTag(field)
+		if tag.skip || field.Name == "_" {
+			continue
+		}
+
+		// Untagged embedded structs are flattened like encoding/json does.
+		if field.Anonymous && !tagged(field) && elemStructType(field.Type).Kind() == reflect.Struct {
+			embedded, err := structColumns(elemStructType(field.Type), visiting)
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, embedded...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if tag.scalar || !isEmbeddable(field.Type) {
+			column := tag.name
+			if tag.column != "" {
+				column = tag.column
+			}
+			columns = append(columns, aliased(tag.key, column))
+			continue
+		}
+
+		nested, err := structColumns(elemStructType(field.Type), visiting)
+		if err != nil {
+			return nil, err
+		}
+
+		resource := tag.name
+		if tag.table != "" {
+			resource = tag.table
+		}
+		resource = aliased(tag.key, resource)
+		if tag.hint != "" {
+			resource += "!" + tag.hint
+		}
+		if tag.inner {
+			resource += "!inner"
+		}
+		columns = append(columns, resource+"("+strings.Join(nested, ",")+")")
+	}
+	return columns, nil
+}
+
+// aliased returns the column selected under alias, omitting the alias when encoding/json would match the
+// column anyway.
+func aliased(alias, column string) string {
+	if strings.EqualFold(alias, column) {
+		return column
+	}
+	return alias + ":" + column
+}
+
+func tagged(field reflect.StructField) bool {
+	_, hasJSON := field.Tag.Lookup("json")
+	_, hasPostgrest := field.Tag.Lookup("postgrest")
+	return hasJSON || hasPostgrest
+}
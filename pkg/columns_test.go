@@ -0,0 +1,109 @@
+package postgrest_go
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type columnsAudit struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type columnsOwner struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+}
+
+type columnsTag struct {
+	Name string `json:"name"`
+}
+
+type columnsProject struct {
+	columnsAudit
+	ID       int             `json:"id"`
+	Title    string          `json:"title" postgrest:",column=name"`
+	Settings map[string]bool `json:"settings"`
+	Meta     struct {
+		Color string `json:"color"`
+	} `json:"meta" postgrest:",scalar"`
+	Owner    *columnsOwner   `json:"owner" postgrest:",table=users,hint=projects_owner_id_fkey"`
+	Tags     []columnsTag    `json:"tags" postgrest:",inner"`
+	Raw      json.RawMessage `json:"raw"`
+	Internal string          `json:"-"`
+	secret   string
+}
+
+func TestSelectColumns(t *testing.T) {
+	got, err := SelectColumns([]columnsProject{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "created_at,id,title:name,settings,meta,owner:users!projects_owner_id_fkey(id,email),tags!inner(name),raw"
+	if got != want {
+		t.Errorf("expected columns == %s, got %s", want, got)
+	}
+}
+
+func TestSelectColumns_Recursive(t *testing.T) {
+	type node struct {
+		ID       int     `json:"id"`
+		Children []*node `json:"children"`
+	}
+
+	if _, err := SelectColumns(node{}); err == nil {
+		t.Error("expected error for recursive type")
+	}
+}
+
+func TestTypedTable_SelectFields(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("select"); got != "id,email" {
+			t.Errorf("expected param select == %s, got %s", "id,email", got)
+		}
+		w.Write([]byte(`[{"id":1,"email":"a@example.com"}]`))
+	})
+
+	owners, err := From[columnsOwner](client, "users").SelectFields().All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(owners) != 1 || owners[0].Email != "a@example.com" {
+		t.Errorf("unexpected owners %+v", owners)
+	}
+}
+
+func TestRequestBuilder_SelectStructError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request to be sent")
+	})
+
+	var v interface{}
+	if err := client.From("users").SelectStruct(42).Execute(&v); err == nil {
+		t.Error("expected error for non-struct value")
+	}
+}
+
+func TestSelectColumns_PostgrestName(t *testing.T) {
+	type account struct {
+		ID        int    `postgrest:"id"`
+		OwnerName string `json:"ownerName" postgrest:"owner_name"`
+		Email     string `json:"email" postgrest:"email"`
+		Owner     struct {
+			Name string `json:"name"`
+		} `json:"owner" postgrest:"users"`
+	}
+
+	got, err := SelectColumns(account{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "id,ownerName:owner_name,email,owner:users(name)"
+	if got != want {
+		t.Errorf("expected columns == %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,73 @@
+package postgrest_go
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CostBudget limits the estimated cost of SELECT requests. Before such a request is executed its plan is
+// retrieved through Explain and checked against the budget. Zero fields are not enforced.
+type CostBudget struct {
+	// MaxTotalCost is the maximum total cost of the plan in Postgres planner units.
+	MaxTotalCost float64
+	// MaxPlanRows is the maximum number of rows the planner expects the query to return.
+	MaxPlanRows float64
+}
+
+func (cb CostBudget) enabled() bool {
+	return cb.MaxTotalCost > 0 || cb.MaxPlanRows > 0
+}
+
+// CostExceededError is returned when the plan of a request exceeds its cost budget. The request is not executed.
+type CostExceededError struct {
+	Budget CostBudget
+	Plan   *Plan
+}
+
+func (e *CostExceededError) Error() string {
+	return fmt.Sprintf("query exceeds cost budget: total cost %.2f (max %.2f), planned rows %.0f (max %.0f)",
+		e.Plan.Plan.TotalCost, e.Budget.MaxTotalCost, e.Plan.Plan.PlanRows, e.Budget.MaxPlanRows)
+}
+
+// WithCostBudget rejects SELECT requests whose plan exceeds budget with a CostExceededError.
+// It requires db-plan-enabled on the server and costs an extra request per query.
+func WithCostBudget(budget CostBudget) ClientOption {
+	return func(c *Client) {
+		c.costBudget = budget
+	}
+}
+
+// CostBudget overrides the cost budget set on the client for this request.
+// Passing the zero CostBudget disables the check.
+func (b *SelectRequestBuilder) CostBudget(budget CostBudget) *SelectRequestBuilder {
+	b.costBudget = &budget
+	return b
+}
+
+// checkCost explains the request and returns a CostExceededError if it exceeds the cost budget.
+func (b *QueryRequestBuilder) checkCost(ctx context.Context) error {
+	if b.httpMethod != http.MethodGet && b.httpMethod != http.MethodHead {
+		return nil
+	}
+
+	budget := b.client.costBudget
+	if b.costBudget != nil {
+		budget = *b.costBudget
+	}
+	if !budget.enabled() {
+		return nil
+	}
+
+	plan, err := b.ExplainWithContext(ctx, ExplainOptions{})
+	if err != nil {
+		return err
+	}
+
+	root := plan.Plan
+	if (budget.MaxTotalCost > 0 && root.TotalCost > budget.MaxTotalCost) ||
+		(budget.MaxPlanRows > 0 && root.PlanRows > budget.MaxPlanRows) {
+		return &CostExceededError{Budget: budget, Plan: plan}
+	}
+	return nil
+}
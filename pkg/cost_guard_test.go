@@ -0,0 +1,86 @@
+package postgrest_go
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func costGuardHandler(totalCost string, executed *bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Accept"), MediaTypePlanJSON) {
+			w.Write([]byte(`[{"Plan":{"Node Type":"Seq Scan","Total Cost":` + totalCost + `,"Plan Rows":500}}]`))
+			return
+		}
+		*executed = true
+		w.Write([]byte(`[]`))
+	}
+}
+
+func TestSelectRequestBuilder_CostBudgetExceeded(t *testing.T) {
+	var executed bool
+	client := newTestClient(t, costGuardHandler("25000", &executed), WithCostBudget(CostBudget{MaxTotalCost: 1000}))
+
+	var rows []map[string]interface{}
+	err := client.From("example_table").Select("*").Execute(&rows)
+
+	var costErr *CostExceededError
+	if !errors.As(err, &costErr) {
+		t.Fatalf("expected *CostExceededError, got %v", err)
+	}
+	if costErr.Plan.Plan.TotalCost != 25000 {
+		t.Errorf("expected TotalCost == %v, got %v", 25000, costErr.Plan.Plan.TotalCost)
+	}
+	if executed {
+		t.Error("expected query not to be executed")
+	}
+}
+
+func TestSelectRequestBuilder_CostBudgetPerRequest(t *testing.T) {
+	var executed bool
+	client := newTestClient(t, costGuardHandler("25000", &executed), WithCostBudget(CostBudget{MaxTotalCost: 1000}))
+
+	var rows []map[string]interface{}
+	if err := client.From("example_table").Select("*").CostBudget(CostBudget{}).Execute(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if !executed {
+		t.Error("expected query to be executed")
+	}
+
+	executed = false
+	err := client.From("example_table").Select("*").CostBudget(CostBudget{MaxPlanRows: 100}).Execute(&rows)
+
+	var costErr *CostExceededError
+	if !errors.As(err, &costErr) {
+		t.Fatalf("expected *CostExceededError, got %v", err)
+	}
+	if executed {
+		t.Error("expected query not to be executed")
+	}
+}
+
+func TestSelectRequestBuilder_CostBudgetCount(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Accept"), MediaTypePlanJSON) {
+			if r.Method != http.MethodGet {
+				t.Errorf("expected plan method == GET, got %s", r.Method)
+			}
+			w.Write([]byte(`[{"Plan":{"Node Type":"Aggregate","Total Cost":25,"Plan Rows":1}}]`))
+			return
+		}
+		if r.Method != http.MethodHead {
+			t.Errorf("expected count method == HEAD, got %s", r.Method)
+		}
+		w.Header().Set("Content-Range", "0-9/42")
+	}, WithCostBudget(CostBudget{MaxTotalCost: 1000}))
+
+	var count int
+	if err := client.From("example_table").Select("*").Count().Execute(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 42 {
+		t.Errorf("expected count == 42, got %d", count)
+	}
+}
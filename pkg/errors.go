@@ -0,0 +1,230 @@
+package postgrest_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxErrorBodySize caps how much of an error response is read.
+	maxErrorBodySize = 64 << 10
+	// maxErrorBodySnippet caps the raw body kept in RequestError.Body.
+	maxErrorBodySnippet = 512
+)
+
+// newRequestError builds the RequestError of a failed response. Bodies that are empty or not PostgREST JSON,
+// like those sent by proxies, fall back to a message derived from the status and body.
+func newRequestError(req *http.Request, resp *http.Response, body []byte) *RequestError {
+	reqError := &RequestError{
+		HTTPStatusCode: resp.StatusCode,
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		Body:           bodySnippet(body),
+		Header:         resp.Header,
+	}
+
+	var payload struct {
+		Message string          `json:"message"`
+		Details json.RawMessage `json:"details"`
+		Hint    json.RawMessage `json:"hint"`
+		Code    string          `json:"code"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && (payload.Code != "" || payload.Message != "") {
+		reqError.Message = payload.Message
+		reqError.Details = jsonText(payload.Details)
+		reqError.Hint = jsonText(payload.Hint)
+		reqError.Code = payload.Code
+		return reqError
+	}
+
+	reqError.Message = http.StatusText(resp.StatusCode)
+	if reqError.Message == "" {
+		reqError.Message = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+	if reqError.Body != "" {
+		reqError.Message += ": " + reqError.Body
+	}
+	return reqError
+}
+
+// jsonText returns a JSON string as is, and any other JSON value other than null in its encoded form.
+func jsonText(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// bodySnippet returns the start of body as trimmed text, cut at a valid UTF-8 boundary.
+func bodySnippet(body []byte) string {
+	if len(body) > maxErrorBodySnippet {
+		body = body[:maxErrorBodySnippet]
+		for len(body) > 0 && !utf8.Valid(body) {
+			body = body[:len(body)-1]
+		}
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// Errors matched by errors.Is against a RequestError, classified from PostgREST error codes and SQLSTATEs.
+var (
+	// ErrNotFound matches a single row request where no rows matched (PGRST116 with 0 rows).
+	ErrNotFound = errors.New("no rows found")
+	// ErrIntegrityViolation matches any integrity constraint violation (SQLSTATE class 23).
+	ErrIntegrityViolation = errors.New("integrity constraint violation")
+	// ErrUniqueViolation matches a unique constraint violation (23505).
+	ErrUniqueViolation = errors.New("unique constraint violation")
+	// ErrForeignKeyViolation matches a foreign key constraint violation (23503).
+	ErrForeignKeyViolation = errors.New("foreign key constraint violation")
+	// ErrNotNullViolation matches a not-null constraint violation (23502).
+	ErrNotNullViolation = errors.New("not-null constraint violation")
+	// ErrCheckViolation matches a check constraint violation (23514).
+	ErrCheckViolation = errors.New("check constraint violation")
+	// ErrPermissionDenied matches missing privileges or row-level security violations (42501).
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrJWTExpired matches requests made with an expired JWT.
+	ErrJWTExpired = errors.New("JWT expired")
+	// ErrSchemaCacheStale matches errors caused by the schema cache missing a table, column, function or
+	// relationship, or being unavailable while it's loaded. Reloading the schema cache may fix them.
+	ErrSchemaCacheStale = errors.New("schema cache stale")
+	// ErrStatementTimeout matches statements canceled by the database, usually after reaching their
+	// statement timeout (57014).
+	ErrStatementTimeout = errors.New("statement timeout")
+)
+
+// schemaCacheCodes are the PostgREST codes reported when the schema cache is loading or lacks an object.
+var schemaCacheCodes = map[string]bool{
+	"PGRST002": true, // could not query the database for the schema cache
+	"PGRST200": true, // relationship not found
+	"PGRST202": true, // function not found
+	"PGRST204": true, // column not found
+	"PGRST205": true, // table not found
+}
+
+// Is reports whether the error matches target, which allows classifying it with the sentinel errors
+// such as ErrNotFound or ErrUniqueViolation.
+func (rq *RequestError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return rq.Code == "PGRST116" && strings.Contains(rq.Details, " 0 rows")
+	case ErrIntegrityViolation:
+		return rq.SQLStateClass() == "23"
+	case ErrUniqueViolation:
+		return rq.Code == "23505"
+	case ErrForeignKeyViolation:
+		return rq.Code == "23503"
+	case ErrNotNullViolation:
+		return rq.Code == "23502"
+	case ErrCheckViolation:
+		return rq.Code == "23514"
+	case ErrPermissionDenied:
+		return rq.Code == "42501"
+	case ErrJWTExpired:
+		return (rq.Code == "PGRST301" || rq.Code == "PGRST303") && strings.Contains(strings.ToLower(rq.Message), "expired")
+	case ErrSchemaCacheStale:
+		return schemaCacheCodes[rq.Code]
+	case ErrStatementTimeout:
+		return rq.Code == "57014"
+	}
+	return false
+}
+
+// IsPostgrestError reports whether the error was raised by PostgREST itself rather than by the database.
+func (rq *RequestError) IsPostgrestError() bool {
+	return strings.HasPrefix(rq.Code, "PGRST")
+}
+
+// SQLStateClass returns the class of the SQLSTATE reported by the database, e.g. "23" for integrity
+// constraint violations. It is empty for errors raised by PostgREST.
+func (rq *RequestError) SQLStateClass() string {
+	if len(rq.Code) != 5 || rq.IsPostgrestError() {
+		return ""
+	}
+	return rq.Code[:2]
+}
+
+var (
+	constraintPattern = regexp.MustCompile(`constraint "([^"]+)"`)
+	keyColumnsPattern = regexp.MustCompile(`Key \(([^)]+)\)=`)
+	columnPattern     = regexp.MustCompile(`column "([^"]+)"`)
+	tablePattern      = regexp.MustCompile(`(?:relation|table) "([^"]+)"`)
+)
+
+// Constraint returns the name of the violated constraint, if the error mentions one.
+func (rq *RequestError) Constraint() string {
+	if m := constraintPattern.FindStringSubmatch(rq.Message); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// Columns returns the columns involved in the error, taken from the key in Details of unique and foreign
+// key violations or from the column mentioned in Message.
+func (rq *RequestError) Columns() []string {
+	if m := keyColumnsPattern.FindStringSubmatch(rq.Details); m != nil {
+		columns := strings.Split(m[1], ",")
+		for i, column := range columns {
+			columns[i] = strings.TrimSpace(column)
+		}
+		return columns
+	}
+	if m := columnPattern.FindStringSubmatch(rq.Message); m != nil {
+		return []string{m[1]}
+	}
+	return nil
+}
+
+// Table returns the table mentioned in Message, if any.
+func (rq *RequestError) Table() string {
+	if m := tablePattern.FindStringSubmatch(rq.Message); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// IsNotFound reports whether err matches ErrNotFound.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUniqueViolation reports whether err matches ErrUniqueViolation.
+func IsUniqueViolation(err error) bool {
+	return errors.Is(err, ErrUniqueViolation)
+}
+
+// IsForeignKeyViolation reports whether err matches ErrForeignKeyViolation.
+func IsForeignKeyViolation(err error) bool {
+	return errors.Is(err, ErrForeignKeyViolation)
+}
+
+// IsPermissionDenied reports whether err matches ErrPermissionDenied.
+func IsPermissionDenied(err error) bool {
+	return errors.Is(err, ErrPermissionDenied)
+}
+
+// IsJWTExpired reports whether err matches ErrJWTExpired.
+func IsJWTExpired(err error) bool {
+	return errors.Is(err, ErrJWTExpired)
+}
+
+// IsSchemaCacheStale reports whether err matches ErrSchemaCacheStale.
+func IsSchemaCacheStale(err error) bool {
+	return errors.Is(err, ErrSchemaCacheStale)
+}
+
+// IsTimeout reports whether err was caused by the context deadline or the statement timeout being reached.
+func IsTimeout(err error) bool {
+	var timeoutErr *TimeoutError
+	return errors.As(err, &timeoutErr) || errors.Is(err, ErrStatementTimeout) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,144 @@
+package postgrest_go
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRequestError_Is(t *testing.T) {
+	tests := []struct {
+		err    *RequestError
+		target error
+		want   bool
+	}{
+		{&RequestError{Code: "PGRST116", Details: "The result contains 0 rows"}, ErrNotFound, true},
+		{&RequestError{Code: "PGRST116", Details: "The result contains 2 rows"}, ErrNotFound, false},
+		{&RequestError{Code: "23505"}, ErrUniqueViolation, true},
+		{&RequestError{Code: "23505"}, ErrIntegrityViolation, true},
+		{&RequestError{Code: "23503"}, ErrForeignKeyViolation, true},
+		{&RequestError{Code: "23503"}, ErrUniqueViolation, false},
+		{&RequestError{Code: "42501"}, ErrPermissionDenied, true},
+		{&RequestError{Code: "PGRST301", Message: "JWT expired"}, ErrJWTExpired, true},
+		{&RequestError{Code: "PGRST303", Message: "JWT expired"}, ErrJWTExpired, true},
+		{&RequestError{Code: "PGRST301", Message: "JWSError JWSInvalidSignature"}, ErrJWTExpired, false},
+		{&RequestError{Code: "PGRST002"}, ErrSchemaCacheStale, true},
+		{&RequestError{Code: "PGRST204"}, ErrSchemaCacheStale, true},
+		{&RequestError{Code: "PGRST100"}, ErrSchemaCacheStale, false},
+	}
+
+	for _, tt := range tests {
+		wrapped := fmt.Errorf("wrapped: %w", tt.err)
+		if got := errors.Is(wrapped, tt.target); got != tt.want {
+			t.Errorf("errors.Is(%s, %v) == %v, want %v", tt.err.Code, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestRequestError_Details(t *testing.T) {
+	unique := &RequestError{
+		Code:    "23505",
+		Message: `duplicate key value violates unique constraint "memberships_pkey"`,
+		Details: "Key (org_id, user_id)=(1, 2) already exists.",
+	}
+	if got := unique.Constraint(); got != "memberships_pkey" {
+		t.Errorf("expected constraint == %s, got %s", "memberships_pkey", got)
+	}
+	if got := unique.Columns(); !reflect.DeepEqual(got, []string{"org_id", "user_id"}) {
+		t.Errorf("unexpected columns %v", got)
+	}
+
+	notNull := &RequestError{
+		Code:    "23502",
+		Message: `null value in column "name" of relation "users" violates not-null constraint`,
+	}
+	if got := notNull.Columns(); !reflect.DeepEqual(got, []string{"name"}) {
+		t.Errorf("unexpected columns %v", got)
+	}
+	if got := notNull.Table(); got != "users" {
+		t.Errorf("expected table == %s, got %s", "users", got)
+	}
+	if got := notNull.SQLStateClass(); got != "23" {
+		t.Errorf("expected SQLSTATE class == %s, got %s", "23", got)
+	}
+	if got := (&RequestError{Code: "PGRST116"}).SQLStateClass(); got != "" {
+		t.Errorf("expected no SQLSTATE class for PostgREST errors, got %s", got)
+	}
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"code":"23505","details":"Key (email)=(a@example.com) already exists.","hint":null,"message":"duplicate key value violates unique constraint \"users_email_key\""}`))
+	})
+
+	var rows []map[string]interface{}
+	err := client.From("users").Insert(map[string]string{"email": "a@example.com"}).Execute(&rows)
+	if !IsUniqueViolation(err) {
+		t.Errorf("expected unique violation, got %v", err)
+	}
+	if IsForeignKeyViolation(err) || IsNotFound(err) {
+		t.Errorf("expected only a unique violation, got %v", err)
+	}
+}
+
+func TestRequestError_NonJSONBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+	})
+
+	var rows []map[string]interface{}
+	err := client.From("users").Select("*").Execute(&rows)
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected *RequestError, got %v", err)
+	}
+	if reqErr.HTTPStatusCode != http.StatusBadGateway {
+		t.Errorf("expected HTTPStatusCode == %d, got %d", http.StatusBadGateway, reqErr.HTTPStatusCode)
+	}
+	if reqErr.Method != http.MethodGet || !strings.HasSuffix(reqErr.URL, "/users?select=*") {
+		t.Errorf("unexpected request %s %s", reqErr.Method, reqErr.URL)
+	}
+	if reqErr.Body != "<html><body>502 Bad Gateway</body></html>" {
+		t.Errorf("unexpected body %q", reqErr.Body)
+	}
+	if got := reqErr.Header.Get("Content-Type"); got != "text/html" {
+		t.Errorf("expected header Content-Type == %s, got %s", "text/html", got)
+	}
+	if !strings.Contains(err.Error(), "502 Bad Gateway") {
+		t.Errorf("expected status in error message, got %s", err)
+	}
+}
+
+func TestRequestError_EmptyBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	var rows []map[string]interface{}
+	err := client.Rpc("whoami", nil).Execute(&rows)
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected *RequestError, got %v", err)
+	}
+	if reqErr.HTTPStatusCode != http.StatusUnauthorized || reqErr.Message != "Unauthorized" {
+		t.Errorf("unexpected error %+v", reqErr)
+	}
+	if reqErr.Method != http.MethodPost {
+		t.Errorf("expected method == %s, got %s", http.MethodPost, reqErr.Method)
+	}
+}
+
+func TestBodySnippet(t *testing.T) {
+	body := []byte(strings.Repeat("a", maxErrorBodySnippet-1) + "é")
+	if got := bodySnippet(body); got != strings.Repeat("a", maxErrorBodySnippet-1) {
+		t.Errorf("expected snippet cut before the split rune, got %d bytes", len(got))
+	}
+}
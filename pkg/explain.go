@@ -0,0 +1,147 @@
+package postgrest_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ExplainOptions configures the execution plan requested through Explain.
+// Plans are only returned by servers with db-plan-enabled set.
+type ExplainOptions struct {
+	// Analyze executes the query to report actual timings and row counts.
+	// Note that analyzing a mutation runs it on the server.
+	Analyze  bool
+	Verbose  bool
+	Settings bool
+	Buffers  bool
+	WAL      bool
+}
+
+// mediaType returns the Accept header value requesting a plan with the options set.
+func (o ExplainOptions) mediaType() string {
+	var options []string
+	if o.Analyze {
+		options = append(options, "analyze")
+	}
+	if o.Verbose {
+		options = append(options, "verbose")
+	}
+	if o.Settings {
+		options = append(options, "settings")
+	}
+	if o.Buffers {
+		options = append(options, "buffers")
+	}
+	if o.WAL {
+		options = append(options, "wal")
+	}
+
+	if len(options) == 0 {
+		return MediaTypePlanJSON
+	}
+	return MediaTypePlanJSON + "; options=" + strings.Join(options, "|")
+}
+
+// Plan is the execution plan of a query as reported by EXPLAIN.
+type Plan struct {
+	Plan          PlanNode          `json:"Plan"`
+	PlanningTime  float64           `json:"Planning Time"`
+	ExecutionTime float64           `json:"Execution Time"`
+	Settings      map[string]string `json:"Settings"`
+}
+
+// PlanNode is a single node of the plan tree. Actual values are only set when the plan was analyzed.
+type PlanNode struct {
+	NodeType          string     `json:"Node Type"`
+	RelationName      string     `json:"Relation Name"`
+	Alias             string     `json:"Alias"`
+	IndexName         string     `json:"Index Name"`
+	Filter            string     `json:"Filter"`
+	StartupCost       float64    `json:"Startup Cost"`
+	TotalCost         float64    `json:"Total Cost"`
+	PlanRows          float64    `json:"Plan Rows"`
+	PlanWidth         int        `json:"Plan Width"`
+	ActualStartupTime float64    `json:"Actual Startup Time"`
+	ActualTotalTime   float64    `json:"Actual Total Time"`
+	ActualRows        float64    `json:"Actual Rows"`
+	ActualLoops       float64    `json:"Actual Loops"`
+	Output            []string   `json:"Output"`
+	Plans             []PlanNode `json:"Plans"`
+}
+
+// Walk calls fn for the node and each of its descendants, depth first.
+func (n *PlanNode) Walk(fn func(node *PlanNode)) {
+	fn(n)
+	for i := range n.Plans {
+		n.Plans[i].Walk(fn)
+	}
+}
+
+// Explain returns the execution plan of the query instead of executing it.
+func (b *QueryRequestBuilder) Explain(opts ExplainOptions) (*Plan, error) {
+	return b.ExplainWithContext(context.Background(), opts)
+}
+
+// ExplainWithContext returns the execution plan of the query with the provided context instead of executing it.
+func (b *QueryRequestBuilder) ExplainWithContext(ctx context.Context, opts ExplainOptions) (*Plan, error) {
+	req, err := b.request(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// A HEAD request, as sent by Count, would return the plan without a body.
+	if req.Method == http.MethodHead {
+		req.Method = http.MethodGet
+	}
+
+	req.Header.Set("Accept", opts.mediaType())
+	return b.client.explain(req)
+}
+
+// Explain returns the execution plan of the function call instead of executing it.
+func (r *RpcRequestBuilder) Explain(opts ExplainOptions) (*Plan, error) {
+	return r.ExplainWithContext(context.Background(), opts)
+}
+
+// ExplainWithContext returns the execution plan of the function call with the provided context instead of executing it.
+func (r *RpcRequestBuilder) ExplainWithContext(ctx context.Context, opts ExplainOptions) (*Plan, error) {
+	req, err := r.request(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", opts.mediaType())
+	return r.client.explain(req)
+}
+
+func (c *Client) explain(req *http.Request) (*Plan, error) {
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// EXPLAIN (FORMAT JSON) wraps the plan in a single element array.
+	var plans []Plan
+	if err = json.Unmarshal(body, &plans); err == nil {
+		if len(plans) == 0 {
+			return nil, errors.New("empty plan returned from explain request")
+		}
+		return &plans[0], nil
+	}
+
+	var plan Plan
+	if err = json.Unmarshal(body, &plan); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
@@ -0,0 +1,78 @@
+package postgrest_go
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestExplainOptions_MediaType(t *testing.T) {
+	tests := []struct {
+		opts ExplainOptions
+		want string
+	}{
+		{ExplainOptions{}, "application/vnd.pgrst.plan+json"},
+		{ExplainOptions{Analyze: true}, "application/vnd.pgrst.plan+json; options=analyze"},
+		{ExplainOptions{Analyze: true, Buffers: true, WAL: true}, "application/vnd.pgrst.plan+json; options=analyze|buffers|wal"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.opts.mediaType(); got != tt.want {
+			t.Errorf("expected media type == %s, got %s", tt.want, got)
+		}
+	}
+}
+
+func TestSelectRequestBuilder_Explain(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/vnd.pgrst.plan+json; options=analyze" {
+			t.Errorf("unexpected header Accept %s", got)
+		}
+		w.Header().Set("Content-Type", MediaTypePlanJSON)
+		w.Write([]byte(`[{"Plan":{"Node Type":"Limit","Startup Cost":0.0,"Total Cost":12.5,"Plan Rows":10,"Plan Width":36,
+			"Plans":[{"Node Type":"Seq Scan","Relation Name":"example_table","Total Cost":12.5,"Plan Rows":1000,"Actual Rows":10}]},
+			"Planning Time":0.05,"Execution Time":0.2}]`))
+	})
+
+	plan, err := client.From("example_table").Select("*").Limit(10).Explain(ExplainOptions{Analyze: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plan.Plan.NodeType != "Limit" || plan.Plan.TotalCost != 12.5 {
+		t.Errorf("unexpected root node %+v", plan.Plan)
+	}
+	if plan.ExecutionTime != 0.2 {
+		t.Errorf("expected ExecutionTime == %v, got %v", 0.2, plan.ExecutionTime)
+	}
+
+	var scans []string
+	plan.Plan.Walk(func(node *PlanNode) {
+		if node.NodeType == "Seq Scan" {
+			scans = append(scans, node.RelationName)
+		}
+	})
+	if len(scans) != 1 || scans[0] != "example_table" {
+		t.Errorf("expected a sequential scan on example_table, got %v", scans)
+	}
+}
+
+func TestSelectRequestBuilder_ExplainCount(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected method == GET, got %s", r.Method)
+		}
+		if got := r.Header.Get("Prefer"); got != "count=exact" {
+			t.Errorf("expected header Prefer == count=exact, got %s", got)
+		}
+		w.Header().Set("Content-Type", MediaTypePlanJSON)
+		w.Write([]byte(`[{"Plan":{"Node Type":"Aggregate","Total Cost":25,"Plan Rows":1}}]`))
+	})
+
+	plan, err := client.From("example_table").Select("*").Count().Explain(ExplainOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Plan.NodeType != "Aggregate" {
+		t.Errorf("expected root node Aggregate, got %s", plan.Plan.NodeType)
+	}
+}
@@ -0,0 +1,38 @@
+package postgrest_go
+
+import "encoding/json"
+
+// FeatureCollection is a GeoJSON feature collection as returned by PostgREST for
+// requests made with GeoJSON.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is a single row of a GeoJSON response.
+type Feature struct {
+	Type       string          `json:"type"`
+	ID         json.RawMessage `json:"id,omitempty"`
+	Geometry   *Geometry       `json:"geometry"`
+	Properties json.RawMessage `json:"properties"`
+}
+
+// DecodeProperties unmarshals the non-geometry columns of the feature into v.
+func (f *Feature) DecodeProperties(v interface{}) error {
+	return json.Unmarshal(f.Properties, v)
+}
+
+// Geometry is a GeoJSON geometry object. Coordinates are left undecoded since
+// their shape depends on the geometry type.
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometries  []Geometry      `json:"geometries,omitempty"`
+}
+
+// Point decodes the coordinates of a Point geometry.
+func (g *Geometry) Point() ([]float64, error) {
+	var coords []float64
+	err := json.Unmarshal(g.Coordinates, &coords)
+	return coords, err
+}
@@ -0,0 +1,50 @@
+package postgrest_go
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSelectRequestBuilder_GeoJSON(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != MediaTypeGeoJSON {
+			t.Errorf("expected header Accept == %s, got %s", MediaTypeGeoJSON, got)
+		}
+		if got := r.URL.Query().Get("name"); got != "eq.Amsterdam" {
+			t.Errorf("expected param name == %s, got %s", "eq.Amsterdam", got)
+		}
+		w.Header().Set("Content-Type", MediaTypeGeoJSON)
+		w.Write([]byte(`{"type":"FeatureCollection","features":[
+			{"type":"Feature","geometry":{"type":"Point","coordinates":[4.9,52.37]},"properties":{"id":1,"name":"Amsterdam"}}
+		]}`))
+	})
+
+	var fc FeatureCollection
+	err := client.From("cities").Select("id", "name", "geom").GeoJSON().Eq("name", "Amsterdam").Execute(&fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fc.Features) != 1 {
+		t.Fatalf("expected 1 feature, got %d", len(fc.Features))
+	}
+
+	point, err := fc.Features[0].Geometry.Point()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(point) != 2 || point[0] != 4.9 || point[1] != 52.37 {
+		t.Errorf("unexpected coordinates %v", point)
+	}
+
+	var props struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := fc.Features[0].DecodeProperties(&props); err != nil {
+		t.Fatal(err)
+	}
+	if props.ID != 1 || props.Name != "Amsterdam" {
+		t.Errorf("unexpected properties %+v", props)
+	}
+}
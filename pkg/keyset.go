@@ -0,0 +1,245 @@
+package postgrest_go
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// KeysetColumn is a column used to order and resume keyset pagination.
+type KeysetColumn struct {
+	Name       string
+	Descending bool
+}
+
+// KeysetPage is a single page of rows fetched by a KeysetPaginator.
+type KeysetPage struct {
+	// Rows holds the undecoded rows of the page.
+	Rows []json.RawMessage
+	// Cursor is an opaque token pointing after the last row of the page.
+	// It is empty for the final, empty page.
+	Cursor string
+
+	body []byte
+}
+
+// Decode unmarshals the rows of the page into v, which should be a pointer to a slice.
+func (p *KeysetPage) Decode(v interface{}) error {
+	return json.Unmarshal(p.body, v)
+}
+
+// KeysetPaginator fetches the results of a SELECT request page by page, resuming after the key of the last row
+// instead of using offsets. The key columns must uniquely identify a row and be selected by the request.
+type KeysetPaginator struct {
+	builder  *SelectRequestBuilder
+	pageSize int
+	columns  []KeysetColumn
+	cursor   string
+	done     bool
+}
+
+// keysetCursor is the decoded form of a cursor token.
+type keysetCursor struct {
+	Columns []string          `json:"c"`
+	Values  []json.RawMessage `json:"v"`
+}
+
+// PaginateKeyset returns a KeysetPaginator that fetches the results of the request in pages of pageSize rows
+// ordered by columns. Pagination ends with the first empty page.
+func (b *SelectRequestBuilder) PaginateKeyset(pageSize int, columns ...KeysetColumn) *KeysetPaginator {
+	return &KeysetPaginator{
+		builder:  b,
+		pageSize: pageSize,
+		columns:  columns,
+	}
+}
+
+// After resumes pagination after the row the cursor was taken from.
+// An empty cursor starts from the first row.
+func (p *KeysetPaginator) After(cursor string) *KeysetPaginator {
+	p.cursor = cursor
+	p.done = false
+	return p
+}
+
+// HasNext reports whether there are pages left to fetch.
+func (p *KeysetPaginator) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page. It returns io.EOF when there are no pages left.
+func (p *KeysetPaginator) Next(ctx context.Context) (*KeysetPage, error) {
+	if p.done {
+		return nil, io.EOF
+	}
+	if p.pageSize <= 0 {
+		return nil, errors.New("page size must be positive")
+	}
+	if len(p.columns) == 0 {
+		return nil, errors.New("keyset pagination requires at least one column")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Work on a copy of the request so the cursor filter of one page doesn't leak into the next.
+	b := *p.builder
+	b.params = cloneValues(p.builder.params)
+
+	order := make([]string, len(p.columns))
+	for i, col := range p.columns {
+		direction := "asc"
+		if col.Descending {
+			direction = "desc"
+		}
+		order[i] = col.Name + "." + direction
+	}
+	b.params.Set("order", strings.Join(order, ","))
+	b.params.Set("limit", strconv.Itoa(p.pageSize))
+
+	if p.cursor != "" {
+		values, err := p.decodeCursor(p.cursor)
+		if err != nil {
+			return nil, err
+		}
+		key, filter := keysetFilter(p.columns, values)
+		b.params.Add(key, filter)
+	}
+
+	resp, err := b.ExecuteRawWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []json.RawMessage
+	if err = json.Unmarshal(body, &rows); err != nil {
+		return nil, err
+	}
+
+	// A page shorter than pageSize doesn't mean the end, the server may cap pages at its max-rows setting.
+	page := &KeysetPage{Rows: rows, body: body}
+	if len(rows) == 0 {
+		p.done = true
+		return page, nil
+	}
+
+	if page.Cursor, err = p.encodeCursor(rows[len(rows)-1]); err != nil {
+		return nil, err
+	}
+	p.cursor = page.Cursor
+	return page, nil
+}
+
+// EachPage calls fn for every remaining page until all pages are fetched, fn returns an error or ctx is canceled.
+func (p *KeysetPaginator) EachPage(ctx context.Context, fn func(page *KeysetPage) error) error {
+	for p.HasNext() {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if err = fn(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeCursor builds the cursor token from the key columns of row.
+func (p *KeysetPaginator) encodeCursor(row json.RawMessage) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(row, &fields); err != nil {
+		return "", err
+	}
+
+	cursor := keysetCursor{
+		Columns: make([]string, len(p.columns)),
+		Values:  make([]json.RawMessage, len(p.columns)),
+	}
+	for i, col := range p.columns {
+		value, ok := fields[col.Name]
+		if !ok || string(value) == "null" {
+			return "", fmt.Errorf("keyset column %s is missing or null in the returned row", col.Name)
+		}
+		cursor.Columns[i] = col.Name
+		cursor.Values[i] = value
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor returns the key values stored in the cursor token as filter values.
+func (p *KeysetPaginator) decodeCursor(token string) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid pagination cursor")
+	}
+
+	var cursor keysetCursor
+	if err = json.Unmarshal(data, &cursor); err != nil {
+		return nil, errors.New("invalid pagination cursor")
+	}
+	if len(cursor.Columns) != len(p.columns) || len(cursor.Values) != len(p.columns) {
+		return nil, errors.New("pagination cursor does not match the keyset columns")
+	}
+
+	values := make([]string, len(p.columns))
+	for i, col := range p.columns {
+		if cursor.Columns[i] != col.Name {
+			return nil, errors.New("pagination cursor does not match the keyset columns")
+		}
+
+		var s string
+		if err = json.Unmarshal(cursor.Values[i], &s); err != nil {
+			// Numbers and booleans are used as they are.
+			s = string(cursor.Values[i])
+		}
+		values[i] = queryValueEscaper.Replace(quoteFilterValue(s))
+	}
+	return values, nil
+}
+
+// keysetFilter returns the filter selecting the rows after values. A single column compares directly,
+// multiple columns expand into (a > x) or (a = x and b > y) and so on.
+func keysetFilter(columns []KeysetColumn, values []string) (key, filter string) {
+	operator := func(col KeysetColumn) string {
+		if col.Descending {
+			return "lt"
+		}
+		return "gt"
+	}
+
+	if len(columns) == 1 {
+		return columns[0].Name, operator(columns[0]) + "." + values[0]
+	}
+
+	branches := make([]string, len(columns))
+	for i, col := range columns {
+		conds := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conds = append(conds, columns[j].Name+".eq."+values[j])
+		}
+		conds = append(conds, col.Name+"."+operator(col)+"."+values[i])
+
+		if len(conds) == 1 {
+			branches[i] = conds[0]
+		} else {
+			branches[i] = "and(" + strings.Join(conds, ",") + ")"
+		}
+	}
+	return "or", "(" + strings.Join(branches, ",") + ")"
+}
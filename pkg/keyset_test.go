@@ -0,0 +1,167 @@
+package postgrest_go
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestKeysetFilter(t *testing.T) {
+	key, filter := keysetFilter([]KeysetColumn{{Name: "id"}}, []string{"42"})
+	if key != "id" || filter != "gt.42" {
+		t.Errorf("expected id=gt.42, got %s=%s", key, filter)
+	}
+
+	key, filter = keysetFilter(
+		[]KeysetColumn{{Name: "created_at", Descending: true}, {Name: "id", Descending: true}},
+		[]string{"2024-01-01", "7"})
+	want := "(created_at.lt.2024-01-01,and(created_at.eq.2024-01-01,id.lt.7))"
+	if key != "or" || filter != want {
+		t.Errorf("expected or=%s, got %s=%s", want, key, filter)
+	}
+}
+
+func TestKeysetPaginator_Next(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("order"); got != "id.asc" {
+			t.Errorf("expected param order == %s, got %s", "id.asc", got)
+		}
+
+		after := -1
+		if filter := query.Get("id"); filter != "" {
+			after, _ = strconv.Atoi(strings.TrimPrefix(filter, "gt."))
+		}
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		rows := []map[string]interface{}{}
+		for id := after + 1; id < 5 && len(rows) < limit; id++ {
+			rows = append(rows, map[string]interface{}{"id": id, "name": "row"})
+		}
+		json.NewEncoder(w).Encode(rows)
+	})
+
+	p := client.From("example_table").Select("id", "name").PaginateKeyset(2, KeysetColumn{Name: "id"})
+
+	page, err := p.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Rows) != 2 || page.Cursor == "" {
+		t.Fatalf("unexpected first page %+v", page)
+	}
+
+	// Resume from the token as an API client would.
+	p = client.From("example_table").Select("id", "name").PaginateKeyset(2, KeysetColumn{Name: "id"}).After(page.Cursor)
+
+	var ids []int
+	err = p.EachPage(context.Background(), func(page *KeysetPage) error {
+		var rows []struct{ ID int }
+		if err := page.Decode(&rows); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 3 || ids[0] != 2 || ids[2] != 4 {
+		t.Errorf("expected ids 2..4, got %v", ids)
+	}
+}
+
+func TestKeysetPaginator_InvalidCursor(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request to be sent")
+	})
+
+	cursor, err := (&KeysetPaginator{columns: []KeysetColumn{{Name: "id"}}}).encodeCursor(json.RawMessage(`{"id":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.From("example_table").Select("*").
+		PaginateKeyset(10, KeysetColumn{Name: "created_at"}).
+		After(cursor).
+		Next(context.Background())
+	if err == nil {
+		t.Error("expected error for a cursor of different columns")
+	}
+}
+
+func TestKeysetPaginator_OffsetTimestamp(t *testing.T) {
+	var filter string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		filter = r.URL.Query().Get("or")
+		w.Write([]byte(`[]`))
+	})
+
+	cursor, err := (&KeysetPaginator{columns: []KeysetColumn{{Name: "created_at"}, {Name: "id"}}}).
+		encodeCursor(json.RawMessage(`{"created_at":"2024-01-01T10:00:00+02:00","id":7}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.From("example_table").Select("*").
+		PaginateKeyset(10, KeysetColumn{Name: "created_at"}, KeysetColumn{Name: "id"}).
+		After(cursor).
+		Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `(created_at.gt."2024-01-01T10:00:00+02:00",and(created_at.eq."2024-01-01T10:00:00+02:00",id.gt.7))`
+	if filter != want {
+		t.Errorf("expected param or == %s, got %s", want, filter)
+	}
+}
+
+func TestKeysetPaginator_MaxRows(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		after := -1
+		if filter := r.URL.Query().Get("id"); filter != "" {
+			after, _ = strconv.Atoi(strings.TrimPrefix(filter, "gt."))
+		}
+
+		// The server caps pages at 3 rows.
+		rows := []map[string]interface{}{}
+		for id := after + 1; id < 10 && len(rows) < 3; id++ {
+			rows = append(rows, map[string]interface{}{"id": id})
+		}
+		json.NewEncoder(w).Encode(rows)
+	})
+
+	var count int
+	err := client.From("example_table").Select("id").PaginateKeyset(5, KeysetColumn{Name: "id"}).
+		EachPage(context.Background(), func(page *KeysetPage) error {
+			count += len(page.Rows)
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 10 {
+		t.Errorf("expected 10 rows, got %d", count)
+	}
+}
+
+func TestQuoteFilterValue(t *testing.T) {
+	tests := map[string]string{
+		"42":         "42",
+		"a,b":        `"a,b"`,
+		`say "hi"`:   `"say \"hi\""`,
+		`back\slash`: `"back\\slash"`,
+	}
+	for value, want := range tests {
+		if got := quoteFilterValue(value); got != want {
+			t.Errorf("expected quoteFilterValue(%s) == %s, got %s", value, want, got)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package postgrest_go
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LimitScope selects how requests share a rate or concurrency limit.
+type LimitScope int
+
+const (
+	// LimitGlobal applies a single limit to all requests of the client.
+	LimitGlobal LimitScope = iota
+	// LimitPerTable applies a separate limit to the requests of each table or function.
+	LimitPerTable
+	// LimitPerOperation applies a separate limit to each kind of operation, such as select or insert.
+	LimitPerOperation
+)
+
+// WithRateLimit limits the client to rate requests per second with bursts of up to burst requests.
+// Requests over the limit wait for their turn until their context ends. A rate of zero or less means no limit.
+func WithRateLimit(rate float64, burst int, scope LimitScope) ClientOption {
+	return func(c *Client) {
+		if rate <= 0 {
+			c.Transport.limits().rate = nil
+			return
+		}
+		c.Transport.limits().rate = &keyedLimit[*tokenBucket]{
+			scope: scope,
+			new:   func() *tokenBucket { return newTokenBucket(rate, burst) },
+		}
+	}
+}
+
+// WithMaxInFlight limits the client to max concurrent requests, counting a request as in flight until its
+// response body is closed. Requests over the limit wait for their turn until their context ends.
+// A max of zero or less means no limit.
+func WithMaxInFlight(max int, scope LimitScope) ClientOption {
+	return func(c *Client) {
+		if max <= 0 {
+			c.Transport.limits().inFlight = nil
+			return
+		}
+		c.Transport.limits().inFlight = &keyedLimit[semaphore]{
+			scope: scope,
+			new:   func() semaphore { return make(semaphore, max) },
+		}
+	}
+}
+
+// requestLimits holds the rate and concurrency limits of a transport.
+type requestLimits struct {
+	rate     *keyedLimit[*tokenBucket]
+	inFlight *keyedLimit[semaphore]
+}
+
+func (c *PostgrestTransport) limits() *requestLimits {
+	if c.requestLimits == nil {
+		c.requestLimits = &requestLimits{}
+	}
+	return c.requestLimits
+}
+
+// acquire waits until req may be sent and returns a function releasing its concurrency slot.
+func (l *requestLimits) acquire(req *http.Request, op Operation) (func(), error) {
+	release := func() {}
+	if l == nil {
+		return release, nil
+	}
+
+	ctx := req.Context()
+	if l.inFlight != nil {
+		sem := l.inFlight.get(op)
+		if err := sem.acquire(ctx); err != nil {
+			return nil, err
+		}
+		release = sem.release
+	}
+
+	if l.rate != nil {
+		if err := l.rate.get(op).wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// keyedLimit lazily creates a limit per key of its scope.
+type keyedLimit[L any] struct {
+	scope LimitScope
+	new   func() L
+
+	mu     sync.Mutex
+	limits map[string]L
+}
+
+func (k *keyedLimit[L]) get(op Operation) L {
+	var key string
+	switch k.scope {
+	case LimitPerTable:
+		key = op.Resource
+	case LimitPerOperation:
+		key = op.Kind
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.limits == nil {
+		k.limits = map[string]L{}
+	}
+	limit, ok := k.limits[key]
+	if !ok {
+		limit = k.new()
+		k.limits[key] = limit
+	}
+	return limit
+}
+
+// tokenBucket allows rate events per second with bursts of up to burst events.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait takes a token, waiting for one to become available until ctx ends.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+type semaphore chan struct{}
+
+func (s semaphore) acquire(ctx context.Context) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s semaphore) release() {
+	<-s
+}
+
+// releaseOnClose calls release once the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
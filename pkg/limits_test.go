@@ -0,0 +1,136 @@
+package postgrest_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithMaxInFlight(t *testing.T) {
+	var inFlight, peak int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Write([]byte(`[]`))
+	}, WithMaxInFlight(2, LimitGlobal))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var rows []map[string]interface{}
+			if err := client.From("users").Select("*").Execute(&rows); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", peak)
+	}
+}
+
+func TestWithMaxInFlight_ContextCanceled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}, WithMaxInFlight(1, LimitPerTable))
+
+	// Hold the only slot of the users table with an unclosed body.
+	resp, err := client.From("users").Select("*").ExecuteRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []map[string]interface{}
+	if err := client.From("projects").Select("*").Execute(&rows); err != nil {
+		t.Errorf("expected other tables not to be limited, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = client.From("users").Select("*").ExecuteWithContext(ctx, &rows)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	resp.Close()
+	if err := client.From("users").Select("*").Execute(&rows); err != nil {
+		t.Errorf("expected slot to be released, got %v", err)
+	}
+}
+
+func TestWithLimits_NonPositive(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}, WithRateLimit(0, 0, LimitGlobal), WithMaxInFlight(0, LimitGlobal))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var rows []map[string]interface{}
+	for i := 0; i < 3; i++ {
+		if err := client.From("users").Select("*").ExecuteWithContext(ctx, &rows); err != nil {
+			t.Fatalf("expected no limit, got %v", err)
+		}
+	}
+}
+
+func TestTokenBucket_Wait(t *testing.T) {
+	bucket := newTokenBucket(100, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := bucket.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("expected requests beyond the burst to wait, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := newTokenBucket(0.001, 1).wait(ctx); err != nil {
+		t.Errorf("expected the burst to be available, got %v", err)
+	}
+	if err := (&tokenBucket{rate: 0.001, burst: 1, last: time.Now()}).wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestPostgrestTransport_Operation(t *testing.T) {
+	transport := &PostgrestTransport{baseURL: url.URL{Scheme: "https", Host: "example.com", Path: "/rest/v1/"}}
+
+	tests := []struct {
+		method, path, prefer string
+		want                 Operation
+	}{
+		{http.MethodGet, "/rest/v1/users", "", Operation{"users", "select"}},
+		{http.MethodPost, "/rest/v1/users", "return=representation", Operation{"users", "insert"}},
+		{http.MethodPost, "/rest/v1/users", "return=representation,resolution=merge-duplicates", Operation{"users", "upsert"}},
+		{http.MethodPatch, "/rest/v1/users", "", Operation{"users", "update"}},
+		{http.MethodPost, "/rest/v1/rpc/add", "", Operation{"rpc/add", "rpc"}},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, "https://example.com"+tt.path, nil)
+		req.Header.Set("Prefer", tt.prefer)
+		if got := transport.operation(req); got != tt.want {
+			t.Errorf("operation(%s %s) == %+v, want %+v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
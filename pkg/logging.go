@@ -0,0 +1,182 @@
+package postgrest_go
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+)
+
+// LoggingOptions configures the request logging of WithLogger.
+//
+// Successful requests are logged at debug level, 4xx responses at info level, requests slower than
+// SlowThreshold at warn level and 5xx responses or transport errors at error level.
+type LoggingOptions struct {
+	// Headers logs the request and response headers. Credentials are redacted.
+	Headers bool
+	// Bodies logs the request and response bodies, cut after MaxBodySize bytes.
+	Bodies bool
+	// MaxBodySize caps the logged body size. It defaults to 1KiB.
+	MaxBodySize int
+	// SlowThreshold logs requests that took longer at warn level. Zero disables it.
+	SlowThreshold time.Duration
+	// RedactHeaders lists additional headers whose values are redacted.
+	RedactHeaders []string
+}
+
+// redactedHeaders are the headers whose values are never logged.
+var redactedHeaders = []string{"Authorization", "Apikey", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// WithLogger logs every request sent by the client to handler.
+func WithLogger(handler slog.Handler, opts LoggingOptions) ClientOption {
+	return func(c *Client) {
+		if opts.MaxBodySize <= 0 {
+			opts.MaxBodySize = 1 << 10
+		}
+		c.Transport.logger = &requestLogger{logger: slog.New(handler), opts: opts}
+	}
+}
+
+// debugLogger is used when Client.Debug is set and no logger was configured.
+func debugLogger() *requestLogger {
+	handler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return &requestLogger{
+		logger: slog.New(handler),
+		opts:   LoggingOptions{Headers: true, MaxBodySize: 1 << 10},
+	}
+}
+
+type requestLogger struct {
+	logger *slog.Logger
+	opts   LoggingOptions
+}
+
+// requestBody returns the start of the request body without consuming it.
+func (l *requestLogger) requestBody(req *http.Request) string {
+	if !l.opts.Bodies || req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(body, int64(l.opts.MaxBodySize)))
+	return string(data)
+}
+
+// responseBody returns the start of the response body and replaces it so it can still be read in full.
+func (l *requestLogger) responseBody(resp *http.Response) string {
+	if !l.opts.Bodies || resp == nil {
+		return ""
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, int64(l.opts.MaxBodySize)))
+	resp.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(data), resp.Body),
+		Closer: resp.Body,
+	}
+	return string(data)
+}
+
+// log logs a request that completed with resp or err after duration.
+func (l *requestLogger) log(req *http.Request, op Operation, resp *http.Response, err error, duration time.Duration, reqBody string) {
+	ctx := req.Context()
+
+	level := slog.LevelDebug
+	switch {
+	case err != nil || resp.StatusCode >= 500:
+		level = slog.LevelError
+	case l.opts.SlowThreshold > 0 && duration >= l.opts.SlowThreshold:
+		level = slog.LevelWarn
+	case resp.StatusCode >= 400:
+		level = slog.LevelInfo
+	}
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.String("resource", op.Resource),
+		slog.String("operation", op.Kind),
+		slog.Duration("duration", duration),
+	}
+	if l.opts.SlowThreshold > 0 && duration >= l.opts.SlowThreshold {
+		attrs = append(attrs, slog.Bool("slow", true))
+	}
+	if l.opts.Headers {
+		attrs = append(attrs, l.headerAttr("request_headers", req.Header))
+	}
+	if reqBody != "" {
+		attrs = append(attrs, slog.String("request_body", reqBody))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		l.logger.LogAttrs(ctx, level, "postgrest request failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if header := resp.Header.Get("Server-Timing"); header != "" {
+		attrs = append(attrs, serverTimingAttr(ParseServerTiming(header)))
+	}
+	if l.opts.Headers {
+		attrs = append(attrs, l.headerAttr("response_headers", resp.Header))
+	}
+	if body := l.responseBody(resp); body != "" {
+		attrs = append(attrs, slog.String("response_body", body))
+	}
+	l.logger.LogAttrs(ctx, level, "postgrest request", attrs...)
+}
+
+// headerAttr returns the headers as a group with credentials redacted.
+func (l *requestLogger) headerAttr(key string, header http.Header) slog.Attr {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "[REDACTED]")
+		}
+	}
+	for _, name := range l.opts.RedactHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "[REDACTED]")
+		}
+	}
+
+	attrs := make([]any, 0, len(redacted))
+	for name, values := range redacted {
+		if len(values) == 1 {
+			attrs = append(attrs, slog.String(name, values[0]))
+		} else {
+			attrs = append(attrs, slog.Any(name, values))
+		}
+	}
+	return slog.Group(key, attrs...)
+}
+
+// serverTimingAttr returns the server timings as a group of durations.
+func serverTimingAttr(st ServerTiming) slog.Attr {
+	attrs := []any{
+		slog.Duration("jwt", st.JWT),
+		slog.Duration("parse", st.Parse),
+		slog.Duration("plan", st.Plan),
+		slog.Duration("transaction", st.Transaction),
+		slog.Duration("response", st.Response),
+	}
+	for name, d := range st.Other {
+		attrs = append(attrs, slog.Duration(name, d))
+	}
+	return slog.Group("server_timing", attrs...)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
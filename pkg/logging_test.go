@@ -0,0 +1,107 @@
+package postgrest_go
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server-Timing", "plan;dur=2.5")
+		w.Write([]byte(`[{"id":1,"name":"` + strings.Repeat("x", 64) + `"}]`))
+	}, WithTokenAuth("s3cr3t"), WithLogger(handler, LoggingOptions{Headers: true, Bodies: true, MaxBodySize: 16}))
+
+	var rows []map[string]interface{}
+	if err := client.From("users").Insert(map[string]int{"id": 1}).Execute(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || len(rows[0]["name"].(string)) != 64 {
+		t.Errorf("expected the full response body to be decoded, got %v", rows)
+	}
+
+	lines := decodeLogLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 log line, got %d", len(lines))
+	}
+	entry := lines[0]
+
+	if entry["level"] != "DEBUG" || entry["status"] != float64(200) || entry["operation"] != "insert" || entry["resource"] != "users" {
+		t.Errorf("unexpected log entry %v", entry)
+	}
+	if _, ok := entry["duration"]; !ok {
+		t.Error("expected duration to be logged")
+	}
+	if got := entry["request_headers"].(map[string]interface{})["Authorization"]; got != "[REDACTED]" {
+		t.Errorf("expected Authorization to be redacted, got %v", got)
+	}
+	if strings.Contains(buf.String(), "s3cr3t") {
+		t.Error("expected token not to be logged")
+	}
+	if got := entry["request_body"]; got != `{"id":1}` {
+		t.Errorf("unexpected request body %v", got)
+	}
+	if got := entry["response_body"]; got != `[{"id":1,"name":` {
+		t.Errorf("expected response body cut at 16 bytes, got %v", got)
+	}
+	if got := entry["server_timing"].(map[string]interface{})["plan"]; got != float64(2500*time.Microsecond) {
+		t.Errorf("expected server timing plan == 2.5ms, got %v", got)
+	}
+}
+
+func TestWithLogger_Levels(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	status := http.StatusOK
+	delay := time.Duration(0)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(status)
+		w.Write([]byte(`[]`))
+	}, WithLogger(handler, LoggingOptions{SlowThreshold: 20 * time.Millisecond}))
+
+	var rows []map[string]interface{}
+	client.From("users").Select("*").Execute(&rows)
+
+	status = http.StatusInternalServerError
+	client.From("users").Select("*").Execute(&rows)
+
+	status, delay = http.StatusOK, 30*time.Millisecond
+	client.From("users").Select("*").Execute(&rows)
+
+	lines := decodeLogLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected debug entries to be filtered out, got %d lines", len(lines))
+	}
+	if lines[0]["level"] != "ERROR" || lines[0]["status"] != float64(500) {
+		t.Errorf("expected an error entry, got %v", lines[0])
+	}
+	if lines[1]["level"] != "WARN" || lines[1]["slow"] != true {
+		t.Errorf("expected a slow request warning, got %v", lines[1])
+	}
+}
@@ -0,0 +1,188 @@
+package postgrest_go
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Match adds a filter for every entry of a map or every non-zero field of a struct.
+//
+// Map keys are used as columns and entries compare with eq, or with in for slice values.
+// Struct fields are named like in SelectColumns; nil pointers and zero values are skipped, so pointers
+// can be used to filter on zero values. Empty slices are skipped for both maps and structs. The operator defaults to eq, or in for slices, and can be set with
+// the op option of the postgrest tag:
+//
+//	Name    string   `postgrest:"name,op=ilike"`
+//	MinAge  int      `postgrest:"age,op=gte"`
+//	Roles   []string `postgrest:"role,op=in"`
+//	Ignored string   `postgrest:"-"`
+func (b *FilterRequestBuilder) Match(v interface{}) *FilterRequestBuilder {
+	if err := b.match(reflect.ValueOf(v)); err != nil && b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+func (b *FilterRequestBuilder) match(v reflect.Value) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		return b.matchMap(v)
+	case reflect.Struct:
+		return b.matchStruct(v)
+	case reflect.Invalid:
+		return nil
+	default:
+		return fmt.Errorf("cannot match on %s, expected a map or struct", v.Type())
+	}
+}
+
+func (b *FilterRequestBuilder) matchMap(v reflect.Value) error {
+	if v.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("cannot match on %s, expected string keys", v.Type())
+	}
+
+	keys := make([]string, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		if err := b.matchValue(key, "", value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *FilterRequestBuilder) matchStruct(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := parseFieldTag(field)
+		if tag.skip {
+			continue
+		}
+
+		value := v.Field(i)
+		if field.Anonymous && !tagged(field) && elemStructType(field.Type).Kind() == reflect.Struct {
+			if err := b.match(value); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() || value.IsZero() {
+			continue
+		}
+
+		column := tag.name
+		if tag.column != "" {
+			column = tag.column
+		}
+		if err := b.matchValue(column, tag.op, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchValue adds the filter comparing column with value using operator, or a default based on the value.
+func (b *FilterRequestBuilder) matchValue(column, operator string, value reflect.Value) error {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	isList := (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && value.Type().Elem().Kind() != reflect.Uint8
+	if isList && value.Len() == 0 {
+		// An empty list would produce in.(), matching no rows, rather than no filter.
+		return nil
+	}
+	if operator == "" {
+		operator = "eq"
+		if isList {
+			operator = "in"
+		}
+	}
+
+	if isList {
+		values := make([]string, value.Len())
+		for i := range values {
+			s, err := formatMatchValue(value.Index(i))
+			if err != nil {
+				return fmt.Errorf("cannot match column %s: %w", column, err)
+			}
+			values[i] = SanitizeParam(s)
+		}
+
+		switch operator {
+		case "in":
+			b.Filter(column, operator, "("+strings.Join(values, ",")+")")
+		case "cs", "cd", "ov":
+			b.Filter(column, operator, "{"+strings.Join(values, ",")+"}")
+		default:
+			return fmt.Errorf("cannot match column %s with operator %s on a list", column, operator)
+		}
+		return nil
+	}
+
+	s, err := formatMatchValue(value)
+	if err != nil {
+		return fmt.Errorf("cannot match column %s: %w", column, err)
+	}
+
+	switch operator {
+	case "like", "ilike":
+		b.Filter(column, operator, SanitizePatternParam(s))
+	case "is":
+		b.Filter(column, operator, s)
+	default:
+		b.Filter(column, operator, SanitizeParam(s))
+	}
+	return nil
+}
+
+// formatMatchValue formats a scalar value as a filter criteria.
+func formatMatchValue(v reflect.Value) (string, error) {
+	if v.CanInterface() {
+		switch value := v.Interface().(type) {
+		case time.Time:
+			return value.Format(time.RFC3339Nano), nil
+		case encoding.TextMarshaler:
+			text, err := value.MarshalText()
+			return string(text), err
+		case fmt.Stringer:
+			return value.String(), nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %s", v.Type())
+	}
+}
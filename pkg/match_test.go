@@ -0,0 +1,98 @@
+package postgrest_go
+
+import (
+	"net/url"
+	"testing"
+)
+
+func newMatchBuilder() *FilterRequestBuilder {
+	client := NewClient(url.URL{Scheme: "https", Host: "example.com"})
+	return &FilterRequestBuilder{
+		QueryRequestBuilder: QueryRequestBuilder{
+			client: client,
+			path:   "/example_table",
+			params: url.Values{},
+		},
+	}
+}
+
+func TestFilterRequestBuilder_MatchStruct(t *testing.T) {
+	active := false
+	search := struct {
+		Name    string   `json:"name" postgrest:",op=ilike"`
+		MinAge  int      `postgrest:"age,op=gte"`
+		Roles   []string `json:"role"`
+		Active  *bool    `json:"active"`
+		Deleted *bool    `json:"deleted"`
+		Team    string   `json:"team"`
+		Ignored string   `json:"-"`
+	}{
+		Name:    "%ali%",
+		MinAge:  18,
+		Roles:   []string{"admin", "dev"},
+		Active:  &active,
+		Ignored: "x",
+	}
+
+	builder := newMatchBuilder().Match(search)
+	if builder.err != nil {
+		t.Fatal(builder.err)
+	}
+
+	want := url.Values{
+		"name":   {"ilike.*ali*"},
+		"age":    {"gte.18"},
+		"role":   {"in.(admin,dev)"},
+		"active": {"eq.false"},
+	}
+	if got := builder.params.Encode(); got != want.Encode() {
+		t.Errorf("expected params == %s, got %s", want.Encode(), got)
+	}
+}
+
+func TestFilterRequestBuilder_MatchMap(t *testing.T) {
+	builder := newMatchBuilder().Match(map[string]interface{}{
+		"status": "open",
+		"id":     []int{1, 2},
+		"count":  0,
+		"owner":  nil,
+	})
+	if builder.err != nil {
+		t.Fatal(builder.err)
+	}
+
+	want := "count=eq.0&id=in.%281%2C2%29&status=eq.open"
+	if got := builder.params.Encode(); got != want {
+		t.Errorf("expected params == %s, got %s", want, got)
+	}
+}
+
+func TestFilterRequestBuilder_MatchInvalid(t *testing.T) {
+	if builder := newMatchBuilder().Match("status"); builder.err == nil {
+		t.Error("expected error when matching on a string")
+	}
+
+	if builder := newMatchBuilder().Match(map[string]interface{}{"id": []int{1}, "x": struct{}{}}); builder.err == nil {
+		t.Error("expected error for unsupported value")
+	}
+}
+
+func TestFilterRequestBuilder_MatchEmptyList(t *testing.T) {
+	search := struct {
+		Roles []string `json:"role"`
+		Team  string   `json:"team"`
+	}{
+		Roles: []string{},
+		Team:  "core",
+	}
+
+	builder := newMatchBuilder().Match(search).Match(map[string]interface{}{"tags": []string{}})
+	if builder.err != nil {
+		t.Fatal(builder.err)
+	}
+
+	want := "team=eq.core"
+	if got := builder.params.Encode(); got != want {
+		t.Errorf("expected params == %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,58 @@
+package postgrest_go
+
+import (
+	"io"
+	"net/http"
+)
+
+// Media types understood by PostgREST that can be requested through the Accept header.
+const (
+	MediaTypeJSON        = "application/json"
+	MediaTypeObjectJSON  = "application/vnd.pgrst.object+json"
+	MediaTypeArrayJSON   = "application/vnd.pgrst.array+json"
+	MediaTypeCSV         = "text/csv"
+	MediaTypeOctetStream = "application/octet-stream"
+	MediaTypeGeoJSON     = "application/geo+json"
+	MediaTypePlanJSON    = "application/vnd.pgrst.plan+json"
+	MediaTypeAny         = "*/*"
+)
+
+// withNullsStripped returns the JSON media type requesting null valued fields to be omitted.
+// Media types other than JSON are returned unchanged.
+func withNullsStripped(mediaType string) string {
+	switch mediaType {
+	case "", MediaTypeJSON, MediaTypeArrayJSON:
+		return MediaTypeArrayJSON + ";nulls=stripped"
+	case MediaTypeObjectJSON:
+		return MediaTypeObjectJSON + ";nulls=stripped"
+	default:
+		return mediaType
+	}
+}
+
+// RawResponse is an undecoded response returned by ExecuteRaw.
+type RawResponse struct {
+	// Body is the response body. It must be closed by the caller.
+	Body io.ReadCloser
+	// ContentType is the media type the server responded with.
+	ContentType string
+	StatusCode  int
+	Header      http.Header
+	// ServerTiming holds the durations reported in the Server-Timing header, if any.
+	ServerTiming ServerTiming
+}
+
+func newRawResponse(resp *http.Response) *RawResponse {
+	return &RawResponse{
+		Body:         resp.Body,
+		ContentType:  resp.Header.Get("Content-Type"),
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header,
+		ServerTiming: ParseServerTiming(resp.Header.Get("Server-Timing")),
+	}
+}
+
+// Close closes the response body.
+func (r *RawResponse) Close() error {
+	return r.Body.Close()
+}
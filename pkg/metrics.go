@@ -0,0 +1,211 @@
+package postgrest_go
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MetricsObserver is notified of every request executed by a query or RPC builder. A request that was
+// retried is observed once, covering all attempts.
+type MetricsObserver interface {
+	// RequestStarted is called before the request is sent.
+	RequestStarted(op Operation)
+	// RequestFinished is called once the response headers were received or the request failed.
+	RequestFinished(op Operation, duration time.Duration, err error)
+}
+
+// WithMetrics reports the requests of the client to observer.
+func WithMetrics(observer MetricsObserver) ClientOption {
+	return func(c *Client) {
+		c.metrics = observer
+	}
+}
+
+// ErrorCode returns a short label classifying err for metrics: the PostgREST or SQLSTATE code of a
+// RequestError, or its HTTP status if it has none, "circuit_open", "canceled", "deadline_exceeded" or
+// "network" for other failures. It is empty for a nil error.
+func ErrorCode(err error) string {
+	var reqErr *RequestError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &reqErr):
+		if reqErr.Code != "" {
+			return reqErr.Code
+		}
+		return strconv.Itoa(reqErr.HTTPStatusCode)
+	case errors.Is(err, ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	default:
+		return "network"
+	}
+}
+
+// DefaultLatencyBuckets are the upper bounds of the latency histogram of NewInMemoryMetrics.
+var DefaultLatencyBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// InMemoryMetrics is a MetricsObserver keeping per operation statistics in memory, e.g. for tests and
+// health pages.
+type InMemoryMetrics struct {
+	buckets []time.Duration
+
+	mu    sync.Mutex
+	stats map[Operation]*OperationStats
+}
+
+// OperationStats are the statistics of the requests of one operation.
+type OperationStats struct {
+	Operation
+	// Requests is the number of finished requests.
+	Requests int64
+	// InFlight is the number of requests started but not finished yet.
+	InFlight int64
+	// Errors counts failed requests by ErrorCode.
+	Errors map[string]int64
+	// Latency is the histogram of request durations.
+	Latency LatencyHistogram
+	// ServerTiming sums the durations reported by the server over ServerTimingSamples requests.
+	ServerTiming        ServerTiming
+	ServerTimingSamples int64
+}
+
+// LatencyHistogram counts durations into buckets.
+type LatencyHistogram struct {
+	// Bounds are the inclusive upper bounds of the buckets.
+	Bounds []time.Duration
+	// Counts holds the number of durations per bucket, with an extra last bucket for durations above
+	// all bounds.
+	Counts []int64
+	Sum    time.Duration
+	Max    time.Duration
+}
+
+// NewInMemoryMetrics returns an InMemoryMetrics with the given latency bucket bounds, which default to
+// DefaultLatencyBuckets.
+func NewInMemoryMetrics(buckets ...time.Duration) *InMemoryMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]time.Duration(nil), buckets...)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+
+	return &InMemoryMetrics{buckets: buckets, stats: map[Operation]*OperationStats{}}
+}
+
+// RequestStarted implements MetricsObserver.
+func (m *InMemoryMetrics) RequestStarted(op Operation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.get(op).InFlight++
+}
+
+// RequestFinished implements MetricsObserver.
+func (m *InMemoryMetrics) RequestFinished(op Operation, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := m.get(op)
+	stats.InFlight--
+	stats.Requests++
+	if err != nil {
+		stats.Errors[ErrorCode(err)]++
+	}
+
+	latency := &stats.Latency
+	i := sort.Search(len(latency.Bounds), func(i int) bool { return duration <= latency.Bounds[i] })
+	latency.Counts[i]++
+	latency.Sum += duration
+	if duration > latency.Max {
+		latency.Max = duration
+	}
+}
+
+// ObserveServerTiming implements ServerTimingObserver.
+func (m *InMemoryMetrics) ObserveServerTiming(op Operation, timing ServerTiming) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := m.get(op)
+	stats.ServerTimingSamples++
+
+	sum := &stats.ServerTiming
+	sum.JWT += timing.JWT
+	sum.Parse += timing.Parse
+	sum.Plan += timing.Plan
+	sum.Transaction += timing.Transaction
+	sum.Response += timing.Response
+	for name, d := range timing.Other {
+		if sum.Other == nil {
+			sum.Other = map[string]time.Duration{}
+		}
+		sum.Other[name] += d
+	}
+}
+
+// Snapshot returns a copy of the statistics of every operation, ordered by resource and kind.
+func (m *InMemoryMetrics) Snapshot() []OperationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make([]OperationStats, 0, len(m.stats))
+	for _, stats := range m.stats {
+		copied := *stats
+		copied.Errors = make(map[string]int64, len(stats.Errors))
+		for code, count := range stats.Errors {
+			copied.Errors[code] = count
+		}
+		copied.Latency.Counts = append([]int64(nil), stats.Latency.Counts...)
+		if stats.ServerTiming.Other != nil {
+			copied.ServerTiming.Other = make(map[string]time.Duration, len(stats.ServerTiming.Other))
+			for name, d := range stats.ServerTiming.Other {
+				copied.ServerTiming.Other[name] = d
+			}
+		}
+		snapshot = append(snapshot, copied)
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Resource != snapshot[j].Resource {
+			return snapshot[i].Resource < snapshot[j].Resource
+		}
+		return snapshot[i].Kind < snapshot[j].Kind
+	})
+	return snapshot
+}
+
+func (m *InMemoryMetrics) get(op Operation) *OperationStats {
+	stats, ok := m.stats[op]
+	if !ok {
+		stats = &OperationStats{
+			Operation: op,
+			Errors:    map[string]int64{},
+			Latency: LatencyHistogram{
+				Bounds: m.buckets,
+				Counts: make([]int64, len(m.buckets)+1),
+			},
+		}
+		m.stats[op] = stats
+	}
+	return stats
+}
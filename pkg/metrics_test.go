@@ -0,0 +1,79 @@
+package postgrest_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestInMemoryMetrics(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rpc/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"P0001","message":"raised"}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+
+	metrics := NewInMemoryMetrics(time.Second)
+	WithMetrics(metrics)(client)
+
+	var rows []map[string]interface{}
+	for i := 0; i < 2; i++ {
+		if err := client.From("users").Select("*").Execute(&rows); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := client.Rpc("fail", nil).Execute(&rows); err == nil {
+		t.Fatal("expected error")
+	}
+
+	snapshot := metrics.Snapshot()
+	if len(snapshot) != 2 {
+		t.Fatalf("expected stats for 2 operations, got %d", len(snapshot))
+	}
+
+	rpc, users := snapshot[0], snapshot[1]
+	if rpc.Operation != (Operation{Resource: "rpc/fail", Kind: "rpc"}) || rpc.Requests != 1 || rpc.Errors["P0001"] != 1 {
+		t.Errorf("unexpected rpc stats %+v", rpc)
+	}
+	if users.Operation != (Operation{Resource: "users", Kind: "select"}) || users.Requests != 2 || len(users.Errors) != 0 {
+		t.Errorf("unexpected users stats %+v", users)
+	}
+	if users.InFlight != 0 {
+		t.Errorf("expected no requests in flight, got %d", users.InFlight)
+	}
+	if users.Latency.Counts[0] != 2 || users.Latency.Counts[1] != 0 {
+		t.Errorf("expected both requests in the first bucket, got %v", users.Latency.Counts)
+	}
+
+	// Snapshots are copies.
+	snapshot[1].Errors["x"] = 1
+	if _, ok := metrics.Snapshot()[1].Errors["x"]; ok {
+		t.Error("expected snapshot to be independent of the metrics")
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{&RequestError{Code: "23505"}, "23505"},
+		{fmt.Errorf("wrapped: %w", &RequestError{HTTPStatusCode: 502}), "502"},
+		{&CircuitOpenError{}, "circuit_open"},
+		{context.DeadlineExceeded, "deadline_exceeded"},
+		{errors.New("connection refused"), "network"},
+	}
+
+	for _, tt := range tests {
+		if got := ErrorCode(tt.err); got != tt.want {
+			t.Errorf("ErrorCode(%v) == %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package postgrest_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Page is a single page of rows fetched by a Paginator.
+type Page struct {
+	// Number is the zero-based index of the page.
+	Number int
+	// Offset is the index of the first row of the page.
+	Offset int
+	// Rows holds the undecoded rows of the page.
+	Rows []json.RawMessage
+	// Total is the total number of rows matching the query, or -1 if the server did not report it.
+	Total int64
+	// Truncated is set when the server returned fewer rows than requested although more rows remain,
+	// which happens when the page size exceeds the server's max-rows setting. It is only detected when the
+	// paginator requested the count itself.
+	Truncated bool
+
+	body []byte
+}
+
+// Decode unmarshals the rows of the page into v, which should be a pointer to a slice.
+func (p *Page) Decode(v interface{}) error {
+	return json.Unmarshal(p.body, v)
+}
+
+// Paginator fetches the results of a SELECT request page by page using offsets.
+type Paginator struct {
+	builder  *SelectRequestBuilder
+	pageSize int
+	offset   int
+	page     int
+	total    int64
+	// exact is set when the paginator requested an exact count itself, so total can be relied on to tell
+	// the end of the results and truncated pages apart.
+	exact bool
+	done  bool
+}
+
+// Paginate returns a Paginator that fetches the results of the request in pages of pageSize rows.
+// An exact count is requested with the first page unless a count preference was already set.
+func (b *SelectRequestBuilder) Paginate(pageSize int) *Paginator {
+	return &Paginator{
+		builder:  b,
+		pageSize: pageSize,
+		total:    -1,
+	}
+}
+
+// HasNext reports whether there are pages left to fetch.
+func (p *Paginator) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page. It returns io.EOF when there are no pages left.
+func (p *Paginator) Next(ctx context.Context) (*Page, error) {
+	if p.done {
+		return nil, io.EOF
+	}
+	if p.pageSize <= 0 {
+		return nil, errors.New("page size must be positive")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b := p.builder
+	b.LimitWithOffset(p.pageSize, p.offset)
+
+	countRequested := false
+	if p.page == 0 && !strings.Contains(b.header.Get("Prefer"), "count=") {
+		setPreference(b.header, "count", "exact")
+		countRequested = true
+		p.exact = true
+	}
+
+	resp, err := b.ExecuteRawWithContext(ctx)
+	if countRequested {
+		removePreference(b.header, "count")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []json.RawMessage
+	if err = json.Unmarshal(body, &rows); err != nil {
+		return nil, err
+	}
+
+	if contentRange := resp.Header.Get("Content-Range"); contentRange != "" {
+		_, _, total, err := parseContentRange(contentRange)
+		if err != nil {
+			return nil, err
+		}
+		if total >= 0 {
+			p.total = total
+		}
+	}
+
+	page := &Page{
+		Number: p.page,
+		Offset: p.offset,
+		Rows:   rows,
+		Total:  p.total,
+		body:   body,
+	}
+
+	p.page++
+	p.offset += len(rows)
+
+	// Without an exact total a short page may still be capped by max-rows, so only an empty page ends
+	// pagination. Planned and estimated counts are reported in Total but not relied on.
+	switch {
+	case len(rows) == 0:
+		p.done = true
+	case p.exact && p.total >= 0 && int64(p.offset) >= p.total:
+		p.done = true
+	case p.exact && p.total >= 0 && len(rows) < p.pageSize:
+		// The server capped the page at its max-rows setting, continue with the size it allows.
+		page.Truncated = true
+		p.pageSize = len(rows)
+	}
+
+	return page, nil
+}
+
+// EachPage calls fn for every remaining page until all pages are fetched, fn returns an error or ctx is canceled.
+func (p *Paginator) EachPage(ctx context.Context, fn func(page *Page) error) error {
+	for p.HasNext() {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if err = fn(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EachRow calls fn for every remaining row until all pages are fetched, fn returns an error or ctx is canceled.
+func (p *Paginator) EachRow(ctx context.Context, fn func(row json.RawMessage) error) error {
+	return p.EachPage(ctx, func(page *Page) error {
+		for _, row := range page.Rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// parseContentRange parses a Content-Range header such as "0-24/3573", "0-24/*" or "*/0".
+// Unknown parts are returned as -1.
+func parseContentRange(contentRange string) (start, end, total int64, err error) {
+	rangePart, totalPart, ok := strings.Cut(contentRange, "/")
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("invalid content range %q", contentRange)
+	}
+
+	start, end, total = -1, -1, -1
+	if totalPart != "*" {
+		if total, err = strconv.ParseInt(totalPart, 10, 64); err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid content range %q", contentRange)
+		}
+	}
+
+	if rangePart != "*" {
+		from, to, ok := strings.Cut(rangePart, "-")
+		if !ok {
+			return 0, 0, 0, fmt.Errorf("invalid content range %q", contentRange)
+		}
+		if start, err = strconv.ParseInt(from, 10, 64); err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid content range %q", contentRange)
+		}
+		if end, err = strconv.ParseInt(to, 10, 64); err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid content range %q", contentRange)
+		}
+	}
+
+	return start, end, total, nil
+}
@@ -0,0 +1,192 @@
+package postgrest_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// rangeHandler serves rows 0..total-1 honoring the Range header and capping pages at maxRows.
+func rangeHandler(total, maxRows int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var from, to int
+		fmt.Sscanf(r.Header.Get("Range"), "%d-%d", &from, &to)
+		if to-from+1 > maxRows {
+			to = from + maxRows - 1
+		}
+		if to >= total {
+			to = total - 1
+		}
+
+		rows := []map[string]int{}
+		for i := from; i <= to; i++ {
+			rows = append(rows, map[string]int{"id": i})
+		}
+
+		totalPart := "*"
+		if strings.Contains(r.Header.Get("Prefer"), "count=exact") {
+			totalPart = fmt.Sprint(total)
+		}
+		if len(rows) == 0 {
+			w.Header().Set("Content-Range", "*/"+totalPart)
+		} else {
+			w.Header().Set("Content-Range", fmt.Sprintf("%d-%d/%s", from, to, totalPart))
+		}
+		json.NewEncoder(w).Encode(rows)
+	}
+}
+
+func TestPaginator_EachRow(t *testing.T) {
+	client := newTestClient(t, rangeHandler(23, 100))
+
+	var ids []int
+	err := client.From("example_table").Select("id").Paginate(10).EachRow(context.Background(), func(row json.RawMessage) error {
+		var v struct{ ID int }
+		if err := json.Unmarshal(row, &v); err != nil {
+			return err
+		}
+		ids = append(ids, v.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 23 || ids[0] != 0 || ids[22] != 22 {
+		t.Errorf("expected ids 0..22, got %v", ids)
+	}
+}
+
+func TestPaginator_MaxRowsTruncation(t *testing.T) {
+	client := newTestClient(t, rangeHandler(12, 5))
+
+	p := client.From("example_table").Select("id").Paginate(10)
+
+	var pages []*Page
+	err := p.EachPage(context.Background(), func(page *Page) error {
+		pages = append(pages, page)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pages) != 3 {
+		t.Fatalf("expected 3 pages, got %d", len(pages))
+	}
+	if !pages[0].Truncated {
+		t.Error("expected first page to be marked as truncated")
+	}
+	if pages[0].Total != 12 {
+		t.Errorf("expected Total == %d, got %d", 12, pages[0].Total)
+	}
+	if pages[2].Offset != 10 || len(pages[2].Rows) != 2 {
+		t.Errorf("unexpected last page %+v", pages[2])
+	}
+}
+
+func TestPaginator_ContextCanceled(t *testing.T) {
+	client := newTestClient(t, rangeHandler(23, 100))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := client.From("example_table").Select("id").Paginate(10).EachPage(ctx, func(page *Page) error {
+		calls++
+		cancel()
+		return nil
+	})
+
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 page, got %d", calls)
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		in                string
+		start, end, total int64
+	}{
+		{"0-24/3573", 0, 24, 3573},
+		{"0-24/*", 0, 24, -1},
+		{"*/0", -1, -1, 0},
+	}
+
+	for _, tt := range tests {
+		start, end, total, err := parseContentRange(tt.in)
+		if err != nil {
+			t.Errorf("parseContentRange(%q): %v", tt.in, err)
+			continue
+		}
+		if start != tt.start || end != tt.end || total != tt.total {
+			t.Errorf("parseContentRange(%q) == %d, %d, %d", tt.in, start, end, total)
+		}
+	}
+
+	if _, _, _, err := parseContentRange("garbage"); err == nil {
+		t.Error("expected error for invalid content range")
+	}
+}
+
+func TestPaginator_KeepsPreferences(t *testing.T) {
+	var prefers []string
+	handler := rangeHandler(15, 100)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		prefers = append(prefers, r.Header.Get("Prefer"))
+		handler(w, r)
+	})
+
+	builder := client.From("example_table").Select("id")
+	builder.header.Set("Prefer", "missing=default")
+	if err := builder.Paginate(10).EachPage(context.Background(), func(page *Page) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"missing=default,count=exact", "missing=default"}
+	if strings.Join(prefers, ";") != strings.Join(expected, ";") {
+		t.Errorf("expected Prefer headers %v, got %v", expected, prefers)
+	}
+}
+
+func TestPaginator_EstimatedCount(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var from, to int
+		fmt.Sscanf(r.Header.Get("Range"), "%d-%d", &from, &to)
+		if to >= 23 {
+			to = 22
+		}
+
+		rows := []map[string]int{}
+		for i := from; i <= to; i++ {
+			rows = append(rows, map[string]int{"id": i})
+		}
+
+		// The planner underestimates the number of rows.
+		if len(rows) == 0 {
+			w.Header().Set("Content-Range", "*/8")
+		} else {
+			w.Header().Set("Content-Range", fmt.Sprintf("%d-%d/8", from, to))
+		}
+		json.NewEncoder(w).Encode(rows)
+	})
+
+	builder := client.From("example_table").Select("id")
+	builder.header.Set("Prefer", "count=planned")
+
+	var count int
+	err := builder.Paginate(10).EachRow(context.Background(), func(row json.RawMessage) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 23 {
+		t.Errorf("expected 23 rows, got %d", count)
+	}
+}
@@ -0,0 +1,189 @@
+package postgrest_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Repository offers CRUD operations on a table whose rows are decoded into T and identified by the
+// primary key columns marked with the pk option of the postgrest tag:
+//
+//	type Membership struct {
+//		_      struct{} `postgrest:",table=memberships"`
+//		OrgID  int      `json:"org_id" postgrest:",pk"`
+//		UserID int      `json:"user_id" postgrest:",pk"`
+//		Role   string   `json:"role"`
+//	}
+//
+// Keys are passed in the order of the primary key fields. Columns are selected as by SelectColumns.
+type Repository[T any] struct {
+	client  *Client
+	table   string
+	columns string
+	keys    []string
+}
+
+// NewRepository returns a Repository for T. The table name is taken from the table option of the postgrest tag
+// of a blank field unless table is set.
+func NewRepository[T any](c *Client, table string) (*Repository[T], error) {
+	var row T
+	t := reflect.TypeOf(row)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot create repository for %v, expected a struct", t)
+	}
+
+	columns, err := SelectColumns(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if table == "" {
+		table = tableName(t)
+	}
+	if table == "" {
+		return nil, fmt.Errorf("no table name set for %s", t)
+	}
+
+	keys := primaryKeyColumns(t)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no primary key columns tagged in %s", t)
+	}
+
+	return &Repository[T]{
+		client:  c,
+		table:   table,
+		columns: columns,
+		keys:    keys,
+	}, nil
+}
+
+// Get returns the row with the given key. The returned error matches ErrNotFound when there is no such row.
+func (r *Repository[T]) Get(ctx context.Context, key ...interface{}) (T, error) {
+	q := From[T](r.client, r.table).Select(r.columns)
+	if err := r.matchKey(&q.FilterRequestBuilder, key); err != nil {
+		var zero T
+		return zero, err
+	}
+	return q.One(ctx)
+}
+
+// List returns the rows matching filters, which is a map or struct as accepted by Match.
+// A nil filters returns all rows.
+func (r *Repository[T]) List(ctx context.Context, filters interface{}) ([]T, error) {
+	q := From[T](r.client, r.table).Select(r.columns)
+	q.Match(filters)
+	return q.All(ctx)
+}
+
+// Create inserts row and returns it as stored.
+func (r *Repository[T]) Create(ctx context.Context, row T) (T, error) {
+	m := From[T](r.client, r.table).Insert(row)
+	return r.one(ctx, m)
+}
+
+// Save inserts row or updates the existing row with the same key, and returns it as stored.
+func (r *Repository[T]) Save(ctx context.Context, row T) (T, error) {
+	m := From[T](r.client, r.table).Upsert(row)
+	m.params.Set("on_conflict", strings.Join(r.keys, ","))
+	return r.one(ctx, m)
+}
+
+// Patch updates the columns set in changes on the row with the given key and returns the updated row.
+// The returned error matches ErrNotFound when there is no such row.
+func (r *Repository[T]) Patch(ctx context.Context, changes interface{}, key ...interface{}) (T, error) {
+	m := From[T](r.client, r.table).Update(changes)
+	if err := r.matchKey(m.FilterRequestBuilder, key); err != nil {
+		var zero T
+		return zero, err
+	}
+	return r.one(ctx, m)
+}
+
+// Delete deletes the row with the given key. The returned error matches ErrNotFound when there is no such row.
+func (r *Repository[T]) Delete(ctx context.Context, key ...interface{}) error {
+	m := From[T](r.client, r.table).Delete()
+	if err := r.matchKey(m.FilterRequestBuilder, key); err != nil {
+		return err
+	}
+	_, err := r.one(ctx, m)
+	return err
+}
+
+// matchKey filters b on the primary key columns.
+func (r *Repository[T]) matchKey(b *FilterRequestBuilder, key []interface{}) error {
+	if len(key) != len(r.keys) {
+		return fmt.Errorf("expected %d key values for %s, got %d", len(r.keys), r.table, len(key))
+	}
+
+	for i, column := range r.keys {
+		value, err := formatMatchValue(reflect.ValueOf(key[i]))
+		if err != nil {
+			return fmt.Errorf("invalid key value for %s: %w", column, err)
+		}
+		b.Eq(column, value)
+	}
+	return nil
+}
+
+// one executes the mutation and returns the only affected row.
+func (r *Repository[T]) one(ctx context.Context, m *TypedMutation[T]) (T, error) {
+	var zero T
+	m.params.Set("select", r.columns)
+
+	rows, err := m.All(ctx)
+	if err != nil {
+		return zero, err
+	}
+
+	switch len(rows) {
+	case 0:
+		return zero, ErrNotFound
+	case 1:
+		return rows[0], nil
+	default:
+		return zero, errors.New("more than one row affected, check the primary key columns of " + r.table)
+	}
+}
+
+// primaryKeyColumns returns the columns tagged with the pk option, in field order.
+func primaryKeyColumns(t reflect.Type) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := parseFieldTag(field)
+		if tag.skip {
+			continue
+		}
+
+		if field.Anonymous && !tagged(field) && elemStructType(field.Type).Kind() == reflect.Struct {
+			keys = append(keys, primaryKeyColumns(elemStructType(field.Type))...)
+			continue
+		}
+		if !tag.pk {
+			continue
+		}
+
+		if tag.column != "" {
+			keys = append(keys, tag.column)
+		} else {
+			keys = append(keys, tag.name)
+		}
+	}
+	return keys
+}
+
+// tableName returns the table option of the postgrest tag of a blank field.
+func tableName(t reflect.Type) string {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Name == "_" {
+			if table := parseFieldTag(field).table; table != "" {
+				return table
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package postgrest_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type repoMembership struct {
+	_      struct{} `postgrest:",table=memberships"`
+	OrgID  int      `json:"org_id" postgrest:",pk"`
+	UserID int      `json:"user_id" postgrest:",pk"`
+	Role   string   `json:"role"`
+}
+
+func TestNewRepository(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	repo, err := NewRepository[repoMembership](client, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.table != "memberships" {
+		t.Errorf("expected table == %s, got %s", "memberships", repo.table)
+	}
+	if len(repo.keys) != 2 || repo.keys[0] != "org_id" || repo.keys[1] != "user_id" {
+		t.Errorf("unexpected keys %v", repo.keys)
+	}
+	if repo.columns != "org_id,user_id,role" {
+		t.Errorf("unexpected columns %s", repo.columns)
+	}
+
+	if _, err := NewRepository[typedUser](client, "users"); err == nil {
+		t.Error("expected error for struct without primary key")
+	}
+}
+
+func TestRepository_Get(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("org_id") != "eq.1" || query.Get("user_id") != "eq.2" {
+			t.Errorf("unexpected key filters %s", r.URL.RawQuery)
+		}
+		if r.URL.Path != "/memberships" {
+			t.Errorf("expected path == %s, got %s", "/memberships", r.URL.Path)
+		}
+		w.Write([]byte(`{"org_id":1,"user_id":2,"role":"owner"}`))
+	})
+
+	repo, err := NewRepository[repoMembership](client, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := repo.Get(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Role != "owner" {
+		t.Errorf("expected role == %s, got %s", "owner", m.Role)
+	}
+
+	if _, err := repo.Get(context.Background(), 1); err == nil {
+		t.Error("expected error for incomplete key")
+	}
+}
+
+func TestRepository_Save(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("on_conflict"); got != "org_id,user_id" {
+			t.Errorf("expected param on_conflict == %s, got %s", "org_id,user_id", got)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("expected method == %s, got %s", http.MethodPost, r.Method)
+		}
+
+		var rows []repoMembership
+		json.NewDecoder(r.Body).Decode(&rows)
+		json.NewEncoder(w).Encode(rows)
+	})
+
+	repo, err := NewRepository[repoMembership](client, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := repo.Save(context.Background(), repoMembership{OrgID: 1, UserID: 2, Role: "member"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Role != "member" {
+		t.Errorf("expected role == %s, got %s", "member", saved.Role)
+	}
+}
+
+func TestRepository_PatchNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected method == %s, got %s", http.MethodPatch, r.Method)
+		}
+		w.Write([]byte(`[]`))
+	})
+
+	repo, err := NewRepository[repoMembership](client, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = repo.Patch(context.Background(), map[string]string{"role": "admin"}, 1, 2)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
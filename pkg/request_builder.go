@@ -19,9 +19,20 @@ type RequestError struct {
 	Hint           string `json:"hint"`
 	Code           string `json:"code"`
 	HTTPStatusCode int    `json:"-"`
+
+	// Method and URL identify the failed request.
+	Method string `json:"-"`
+	URL    string `json:"-"`
+	// Body holds the start of the raw response body, which helps diagnosing responses that were not sent
+	// by PostgREST, such as an HTML page from a proxy.
+	Body   string      `json:"-"`
+	Header http.Header `json:"-"`
 }
 
 func (rq *RequestError) Error() string {
+	if rq.Code == "" {
+		return fmt.Sprintf("%s %s: %d %s", rq.Method, rq.URL, rq.HTTPStatusCode, rq.Message)
+	}
 	return fmt.Sprintf("%s: %s", rq.Code, rq.Message)
 }
 
@@ -116,6 +127,14 @@ type QueryRequestBuilder struct {
 	httpMethod string
 	json       interface{}
 	isCount    bool
+	costBudget *CostBudget
+
+	maybeSingle  bool
+	stripNulls   bool
+	versionCheck *versionCheck
+
+	// err is set by builder methods that failed and is returned when the request is executed.
+	err error
 }
 
 // Execute sends the query request and unmarshals the response JSON into the provided object.
@@ -125,35 +144,16 @@ func (b *QueryRequestBuilder) Execute(r interface{}) error {
 
 // ExecuteWithContext sends the query request with the provided context and unmarshals the response JSON into the provided object.
 func (b *QueryRequestBuilder) ExecuteWithContext(ctx context.Context, r interface{}) error {
-	data, err := json.Marshal(b.json)
-	if err != nil {
+	if err := b.checkCost(ctx); err != nil {
 		return err
 	}
-	req, err := http.NewRequestWithContext(ctx, b.httpMethod, b.path, bytes.NewBuffer(data))
-	if err != nil {
-		return err
-	}
-	query, err := url.QueryUnescape(b.params.Encode())
 
+	req, err := b.request(ctx)
 	if err != nil {
 		return err
 	}
 
-	req.URL.RawQuery = query
-
-	req.Header = b.client.Headers()
-
-	// Inject/override custom headers
-	for key, vals := range b.header {
-		for _, val := range vals {
-			req.Header.Set(key, val)
-		}
-	}
-
-	req.URL.Path = req.URL.Path[1:]
-	req.URL = b.client.Transport.baseURL.ResolveReference(req.URL)
-
-	resp, err := b.client.session.Do(req)
+	resp, err := b.client.do(req)
 	if err != nil {
 		return err
 	}
@@ -164,15 +164,10 @@ func (b *QueryRequestBuilder) ExecuteWithContext(ctx context.Context, r interfac
 		return err
 	}
 
-	statusOK := resp.StatusCode >= 200 && resp.StatusCode < 300
-	if !statusOK {
-		reqError := RequestError{HTTPStatusCode: resp.StatusCode}
-
-		if err = json.Unmarshal(body, &reqError); err != nil {
+	if b.versionCheck != nil {
+		if err = b.versionCheck.checkVersion(body); err != nil {
 			return err
 		}
-
-		return &reqError
 	}
 
 	if resp.StatusCode != http.StatusNoContent && r != nil {
@@ -185,6 +180,11 @@ func (b *QueryRequestBuilder) ExecuteWithContext(ctx context.Context, r interfac
 			return json.Unmarshal([]byte(contentRangeParts[1]), r)
 		}
 
+		if b.maybeSingle {
+			_, err = unmarshalMaybeSingle(body, r)
+			return err
+		}
+
 		if err = json.Unmarshal(body, r); err != nil {
 			return err
 		}
@@ -193,6 +193,146 @@ func (b *QueryRequestBuilder) ExecuteWithContext(ctx context.Context, r interfac
 	return nil
 }
 
+// ExecuteRaw sends the query request and returns the undecoded response.
+// The caller is responsible for closing the response body.
+func (b *QueryRequestBuilder) ExecuteRaw() (*RawResponse, error) {
+	return b.ExecuteRawWithContext(context.Background())
+}
+
+// ExecuteRawWithContext sends the query request with the provided context and returns the undecoded response.
+// The caller is responsible for closing the response body.
+func (b *QueryRequestBuilder) ExecuteRawWithContext(ctx context.Context) (*RawResponse, error) {
+	if err := b.checkCost(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := b.request(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := b.client.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.versionCheck != nil {
+		if err = b.versionCheck.checkResponse(resp); err != nil {
+			return nil, err
+		}
+	}
+	return newRawResponse(resp), nil
+}
+
+// ExecuteReader sends the query request and returns the undecoded response body.
+// The caller is responsible for closing it.
+func (b *QueryRequestBuilder) ExecuteReader() (io.ReadCloser, error) {
+	return b.ExecuteReaderWithContext(context.Background())
+}
+
+// ExecuteReaderWithContext sends the query request with the provided context and returns the undecoded response body.
+// The caller is responsible for closing it.
+func (b *QueryRequestBuilder) ExecuteReaderWithContext(ctx context.Context) (io.ReadCloser, error) {
+	resp, err := b.ExecuteRawWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ExecuteTo sends the query request and copies the undecoded response body into w.
+func (b *QueryRequestBuilder) ExecuteTo(w io.Writer) (int64, error) {
+	return b.ExecuteToWithContext(context.Background(), w)
+}
+
+// ExecuteToWithContext sends the query request with the provided context and copies the undecoded response body into w.
+func (b *QueryRequestBuilder) ExecuteToWithContext(ctx context.Context, w io.Writer) (int64, error) {
+	body, err := b.ExecuteReaderWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer body.Close()
+	return io.Copy(w, body)
+}
+
+// Accept sets the media type the response should be returned in, e.g. MediaTypeOctetStream.
+// Responses in a media type other than JSON should be consumed through ExecuteRaw.
+func (b *QueryRequestBuilder) Accept(mediaType string) *QueryRequestBuilder {
+	b.header.Set("Accept", mediaType)
+	return b
+}
+
+// StripNulls omits null valued fields from the returned objects to shrink the response.
+// It requires PostgREST 11.2 or newer.
+func (b *QueryRequestBuilder) StripNulls() *QueryRequestBuilder {
+	b.stripNulls = true
+	return b
+}
+
+// request creates the HTTP request for the query.
+func (b *QueryRequestBuilder) request(ctx context.Context) (*http.Request, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	data, err := json.Marshal(b.json)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, b.httpMethod, b.path, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	query, err := url.QueryUnescape(b.params.Encode())
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.URL.RawQuery = query
+
+	req.Header = b.client.Headers()
+
+	// Inject/override custom headers
+	for key, vals := range b.header {
+		for _, val := range vals {
+			req.Header.Set(key, val)
+		}
+	}
+
+	if b.stripNulls {
+		req.Header.Set("Accept", withNullsStripped(req.Header.Get("Accept")))
+	}
+
+	req.URL.Path = req.URL.Path[1:]
+	req.URL = b.client.Transport.baseURL.ResolveReference(req.URL)
+	return req, nil
+}
+
+// unmarshalMaybeSingle unmarshals the only row of body into r and reports whether there was one.
+// r is left untouched when body holds no rows.
+func unmarshalMaybeSingle(body []byte, r interface{}) (bool, error) {
+	var rows []json.RawMessage
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return false, err
+	}
+
+	switch len(rows) {
+	case 0:
+		return false, nil
+	case 1:
+		return true, json.Unmarshal(rows[0], r)
+	default:
+		return false, &RequestError{
+			Message:        "JSON object requested, multiple (or no) rows returned",
+			Details:        fmt.Sprintf("The result contains %d rows", len(rows)),
+			Code:           "PGRST116",
+			HTTPStatusCode: http.StatusNotAcceptable,
+		}
+	}
+}
+
 // FilterRequestBuilder represents a builder for filter requests.
 type FilterRequestBuilder struct {
 	QueryRequestBuilder
@@ -205,6 +345,20 @@ func (b *FilterRequestBuilder) Not() *FilterRequestBuilder {
 	return b
 }
 
+// Accept sets the media type the response should be returned in, e.g. MediaTypeCSV.
+// Responses in a media type other than JSON should be consumed through ExecuteRaw.
+func (b *FilterRequestBuilder) Accept(mediaType string) *FilterRequestBuilder {
+	b.header.Set("Accept", mediaType)
+	return b
+}
+
+// StripNulls omits null valued fields from the returned objects to shrink the response.
+// It requires PostgREST 11.2 or newer.
+func (b *FilterRequestBuilder) StripNulls() *FilterRequestBuilder {
+	b.stripNulls = true
+	return b
+}
+
 // Filter adds a filter condition to the request.
 func (b *FilterRequestBuilder) Filter(column, operator, criteria string) *FilterRequestBuilder {
 	if b.negateNext {
@@ -361,34 +515,82 @@ func (b *SelectRequestBuilder) OrderBy(column, direction string) *SelectRequestB
 	return b
 }
 
-// Range sets the range of rows to be returned for the SELECT request.
+// Range restricts the results to the rows from index from up to and including index to via the Range header.
 func (b *SelectRequestBuilder) Range(from, to int) *SelectRequestBuilder {
-	b.params.Set("range", fmt.Sprintf("%d-%d", from, to))
+	return b.LimitWithOffset(to-from+1, from)
+}
+
+// MaybeSingle requests at most one row. The row is unmarshaled into the object passed to Execute as a single
+// object; when no rows match the object is left untouched and no error is returned.
+func (b *SelectRequestBuilder) MaybeSingle() *SelectRequestBuilder {
+	b.maybeSingle = true
+	b.limitMaybeSingle()
 	return b
 }
 
-// SingleRow sets the single row behavior for the SELECT request.
+// limitMaybeSingle limits the request to two rows unless a range is set, which is enough to tell a single row
+// from multiple rows without fetching every match.
+func (b *SelectRequestBuilder) limitMaybeSingle() {
+	if b.header.Get("Range") == "" {
+		b.Limit(2)
+	}
+}
+
+// SingleRow is an alias of MaybeSingle.
+//
+// Deprecated: Use MaybeSingle, or Single to fail when no rows match.
 func (b *SelectRequestBuilder) SingleRow() *SelectRequestBuilder {
-	b.params.Set("single-row", "true")
+	return b.MaybeSingle()
+}
+
+// StripNulls omits null valued fields from the returned objects to shrink the response.
+// It requires PostgREST 11.2 or newer.
+func (b *SelectRequestBuilder) StripNulls() *SelectRequestBuilder {
+	b.stripNulls = true
 	return b
 }
 
-// OnlyPayload sets the only payload behavior for the SELECT request.
+// ExecuteMaybe sends the request for at most one row and unmarshals it into r.
+// It reports whether a row was found; r is left untouched otherwise.
+func (b *SelectRequestBuilder) ExecuteMaybe(r interface{}) (bool, error) {
+	return b.ExecuteMaybeWithContext(context.Background(), r)
+}
+
+// ExecuteMaybeWithContext sends the request for at most one row with the provided context and unmarshals it into r.
+// It reports whether a row was found; r is left untouched otherwise.
+func (b *SelectRequestBuilder) ExecuteMaybeWithContext(ctx context.Context, r interface{}) (bool, error) {
+	b.limitMaybeSingle()
+	resp, err := b.Accept(MediaTypeJSON).ExecuteRawWithContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer resp.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	return unmarshalMaybeSingle(body, r)
+}
+
+// OnlyPayload is an alias of StripNulls.
+//
+// Deprecated: Use StripNulls.
 func (b *SelectRequestBuilder) OnlyPayload() *SelectRequestBuilder {
-	b.params.Set("only-payload", "true")
-	return b
+	return b.StripNulls()
 }
 
-// WithoutCount sets the without count behavior for the SELECT request.
+// WithoutCount removes any count preference so the server doesn't compute the total number of rows.
 func (b *SelectRequestBuilder) WithoutCount() *SelectRequestBuilder {
-	b.params.Set("without-count", "true")
+	removePreference(b.header, "count")
 	return b
 }
 
-// SingleValue sets the single value behavior for the SELECT request.
+// SingleValue is an alias of Single.
+//
+// Deprecated: Use Single.
 func (b *SelectRequestBuilder) SingleValue() *SelectRequestBuilder {
-	b.params.Set("single-value", "true")
-	return b
+	return b.Single()
 }
 
 // Limit will restrict the number of results via the Range header.
@@ -403,8 +605,27 @@ func (b *SelectRequestBuilder) LimitWithOffset(size int, start int) *SelectReque
 	return b
 }
 
+// Accept sets the media type the rows should be returned in, e.g. MediaTypeCSV.
+// Responses in a media type other than JSON should be consumed through ExecuteReader or ExecuteTo.
+func (b *SelectRequestBuilder) Accept(mediaType string) *SelectRequestBuilder {
+	b.header.Set("Accept", mediaType)
+	return b
+}
+
+// CSV requests the rows as comma-separated values instead of JSON.
+func (b *SelectRequestBuilder) CSV() *SelectRequestBuilder {
+	return b.Accept(MediaTypeCSV)
+}
+
+// GeoJSON requests the rows as a GeoJSON feature collection. The response can be decoded into a FeatureCollection.
+func (b *SelectRequestBuilder) GeoJSON() *SelectRequestBuilder {
+	return b.Accept(MediaTypeGeoJSON)
+}
+
+// Single requests exactly one row, which is returned as an object instead of an array.
+// The server responds with a PGRST116 error when zero or multiple rows match.
 func (b *SelectRequestBuilder) Single() *SelectRequestBuilder {
-	b.header.Set("Accept", "application/vnd.pgrst.object+json")
+	b.header.Set("Accept", MediaTypeObjectJSON)
 	return b
 }
 
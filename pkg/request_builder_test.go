@@ -1,8 +1,10 @@
 package postgrest_go
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -137,3 +139,232 @@ func TestRequestBuilder_Delete(t *testing.T) {
 		t.Errorf("expected json == %v, got %v", nil, s.json)
 	}
 }
+
+func TestFilterRequestBuilder_Accept(t *testing.T) {
+	var want string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != want {
+			t.Errorf("expected header Accept == %s, got %s", want, got)
+		}
+		if got := r.URL.Query().Get("id"); got != "eq.1" {
+			t.Errorf("expected param id == eq.1, got %s", got)
+		}
+		w.Write([]byte("id\n1\n"))
+	})
+
+	want = MediaTypeCSV
+	var buf strings.Builder
+	_, err := client.From("example_table").Delete().Accept(MediaTypeCSV).Eq("id", "1").ExecuteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "id\n1\n" {
+		t.Errorf("unexpected body %q", buf.String())
+	}
+
+	want = MediaTypeArrayJSON + ";nulls=stripped"
+	resp, err := client.From("example_table").Update(map[string]string{"name": "x"}).StripNulls().Eq("id", "1").ExecuteRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Close()
+}
+
+func TestSelectRequestBuilder_CSV(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != MediaTypeCSV {
+			t.Errorf("expected header Accept == %s, got %s", MediaTypeCSV, got)
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Write([]byte("id,name\n1,foo\n"))
+	})
+
+	var out strings.Builder
+	n, err := client.From("example_table").Select("id", "name").CSV().ExecuteTo(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "id,name\n1,foo\n"; out.String() != want {
+		t.Errorf("expected body == %q, got %q", want, out.String())
+	}
+	if n != int64(out.Len()) {
+		t.Errorf("expected %d bytes copied, got %d", out.Len(), n)
+	}
+}
+
+func TestSelectRequestBuilder_ExecuteReaderError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"42703","message":"column does not exist"}`))
+	})
+
+	_, err := client.From("example_table").Select("nope").CSV().ExecuteReader()
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected *RequestError, got %v", err)
+	}
+	if reqErr.Code != "42703" || reqErr.HTTPStatusCode != http.StatusBadRequest {
+		t.Errorf("unexpected error %+v", reqErr)
+	}
+}
+
+func TestSelectRequestBuilder_Range(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Range"); got != "10-19" {
+			t.Errorf("expected header Range == %s, got %s", "10-19", got)
+		}
+		if got := r.Header.Get("Range-Unit"); got != "items" {
+			t.Errorf("expected header Range-Unit == %s, got %s", "items", got)
+		}
+		if r.URL.Query().Has("range") {
+			t.Errorf("unexpected param range in %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`[]`))
+	})
+
+	var rows []map[string]interface{}
+	if err := client.From("example_table").Select("*").Range(10, 19).Execute(&rows); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSelectRequestBuilder_MaybeSingle(t *testing.T) {
+	var response string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != MediaTypeJSON {
+			t.Errorf("expected header Accept == %s, got %s", MediaTypeJSON, got)
+		}
+		if got := r.Header.Get("Range"); got != "0-1" {
+			t.Errorf("expected header Range == 0-1, got %s", got)
+		}
+		w.Write([]byte(response))
+	})
+
+	type row struct {
+		ID int `json:"id"`
+	}
+
+	response = `[]`
+	var none *row
+	if err := client.From("example_table").Select("id").MaybeSingle().Execute(&none); err != nil {
+		t.Fatal(err)
+	}
+	if none != nil {
+		t.Errorf("expected row to be left nil, got %+v", none)
+	}
+
+	response = `[{"id":1}]`
+	var one *row
+	if err := client.From("example_table").Select("id").MaybeSingle().Execute(&one); err != nil {
+		t.Fatal(err)
+	}
+	if one == nil || one.ID != 1 {
+		t.Errorf("expected row with id 1, got %+v", one)
+	}
+
+	response = `[{"id":1},{"id":2}]`
+	var many *row
+	err := client.From("example_table").Select("id").SingleRow().Execute(&many)
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.Code != "PGRST116" {
+		t.Errorf("expected PGRST116 error, got %v", err)
+	}
+}
+
+func TestSelectRequestBuilder_StripNulls(t *testing.T) {
+	var want string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != want {
+			t.Errorf("expected header Accept == %s, got %s", want, got)
+		}
+		if r.URL.Query().Has("only-payload") {
+			t.Errorf("unexpected param only-payload in %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{}`))
+	})
+
+	var v interface{}
+
+	want = "application/vnd.pgrst.array+json;nulls=stripped"
+	if err := client.From("example_table").Select("*").OnlyPayload().Execute(&v); err != nil {
+		t.Fatal(err)
+	}
+
+	want = "application/vnd.pgrst.object+json;nulls=stripped"
+	if err := client.From("example_table").Select("*").StripNulls().SingleValue().Execute(&v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSelectRequestBuilder_WithoutCount(t *testing.T) {
+	client := NewClient(url.URL{Scheme: "https", Host: "example.com"})
+
+	builder := RequestBuilder{
+		client: client,
+		header: http.Header{},
+		params: url.Values{},
+	}
+	builder.header.Set("Prefer", "count=exact,timeout=5")
+
+	s := builder.Select("*").WithoutCount()
+
+	if got := s.header.Get("Prefer"); got != "timeout=5" {
+		t.Errorf("expected header Prefer == %s, got %s", "timeout=5", got)
+	}
+	if s.params.Has("without-count") {
+		t.Errorf("unexpected param without-count in %s", s.params.Encode())
+	}
+}
+
+func TestSelectRequestBuilder_ExecuteMaybe(t *testing.T) {
+	var response string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Range"); got != "0-1" {
+			t.Errorf("expected header Range == 0-1, got %s", got)
+		}
+		w.Write([]byte(response))
+	})
+
+	row := struct {
+		ID int `json:"id"`
+	}{ID: -1}
+
+	response = `[]`
+	found, err := client.From("example_table").Select("id").ExecuteMaybe(&row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found || row.ID != -1 {
+		t.Errorf("expected no row and untouched target, got found == %v, %+v", found, row)
+	}
+
+	response = `[{"id":3}]`
+	found, err = client.From("example_table").Select("id").ExecuteMaybe(&row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || row.ID != 3 {
+		t.Errorf("expected row with id 3, got found == %v, %+v", found, row)
+	}
+}
+
+func TestSelectRequestBuilder_SingleNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotAcceptable)
+		w.Write([]byte(`{"code":"PGRST116","details":"The result contains 0 rows","hint":null,"message":"JSON object requested, multiple (or no) rows returned"}`))
+	})
+
+	var row map[string]interface{}
+	err := client.From("example_table").Select("*").Single().Execute(&row)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	multiple := &RequestError{Code: "PGRST116", Details: "The result contains 2 rows"}
+	if errors.Is(multiple, ErrNotFound) {
+		t.Error("expected multiple rows not to match ErrNotFound")
+	}
+}
@@ -0,0 +1,184 @@
+package postgrest_go
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried. Requests are retried on network errors, on
+// 429, 502, 503 and 504 responses, while the schema cache is loading (PGRST002) and on serialization
+// failures and deadlocks (40001, 40P01). The zero RetryPolicy doesn't retry.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. It defaults to 100ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. It defaults to 5s. Requests are not retried when the
+	// server asks to wait longer than MaxBackoff through Retry-After.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay grows by after each attempt. It defaults to 2.
+	Multiplier float64
+	// Jitter is the fraction of the delay that is randomized, between 0 and 1.
+	Jitter float64
+	// RetryNonIdempotent allows retrying POST, PATCH and DELETE requests, which may have been applied
+	// by the server even though they failed. Only GET, HEAD, OPTIONS and PUT requests are retried otherwise.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy making up to 3 attempts with exponential backoff and jitter.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// WithRetryPolicy retries failed requests according to policy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// retryableCodes are the error codes of requests that may succeed when retried.
+var retryableCodes = map[string]bool{
+	"PGRST002": true, // schema cache is loading
+	"40001":    true, // serialization_failure
+	"40P01":    true, // deadlock_detected
+}
+
+// shouldRetry reports whether the request should be sent again after attempt failed with err, and how
+// long to wait before doing so.
+func (p RetryPolicy) shouldRetry(req *http.Request, attempt int, err error) (time.Duration, bool) {
+	if err == nil || attempt >= p.MaxAttempts || req.Context().Err() != nil {
+		return 0, false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return 0, false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+
+	if errors.Is(err, ErrCircuitOpen) {
+		return 0, false
+	}
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		// Failures other than error responses are network errors.
+		return p.backoff(attempt), true
+	}
+
+	switch {
+	case retryableCodes[reqErr.Code]:
+	case reqErr.HTTPStatusCode == http.StatusTooManyRequests,
+		reqErr.HTTPStatusCode == http.StatusBadGateway,
+		reqErr.HTTPStatusCode == http.StatusServiceUnavailable,
+		reqErr.HTTPStatusCode == http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+
+	if wait, ok := retryAfter(reqErr.Header); ok {
+		if wait > p.maxBackoff() {
+			return 0, false
+		}
+		return wait, true
+	}
+	return p.backoff(attempt), true
+}
+
+func (p RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff <= 0 {
+		return 5 * time.Second
+	}
+	return p.MaxBackoff
+}
+
+// backoff returns the delay after the given attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max, multiplier := p.InitialBackoff, p.maxBackoff(), p.Multiplier
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	wait := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if wait > float64(max) {
+		wait = float64(max)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		wait = wait*(1-jitter) + wait*jitter*rand.Float64()
+	}
+	return time.Duration(wait)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter parses the Retry-After header, given either in seconds or as an HTTP date.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// retryRequest waits before returning a copy of req with a fresh body, or returns the context error
+// if the request's context ends first.
+func retryRequest(req *http.Request, wait time.Duration) (*http.Request, error) {
+	if err := sleep(req.Context(), wait); err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return retry, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
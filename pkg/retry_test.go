@@ -0,0 +1,156 @@
+package postgrest_go
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestRetryPolicy_RetriesTransientFailures(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"code":"PGRST002","message":"Could not query the database for the schema cache. Retrying."}`))
+			return
+		}
+		w.Write([]byte(`[{"id":1}]`))
+	}, WithRetryPolicy(testRetryPolicy()))
+
+	var rows []map[string]interface{}
+	if err := client.From("users").Select("*").Execute(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 || len(rows) != 1 {
+		t.Errorf("expected 3 attempts and 1 row, got %d attempts and %d rows", attempts, len(rows))
+	}
+}
+
+func TestRetryPolicy_NonIdempotent(t *testing.T) {
+	attempts := 0
+	var bodies []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"code":"40001","message":"could not serialize access due to concurrent update"}`))
+	}
+
+	client := newTestClient(t, handler, WithRetryPolicy(testRetryPolicy()))
+	var rows []map[string]interface{}
+	client.From("users").Insert(map[string]int{"id": 1}).Execute(&rows)
+	if attempts != 1 {
+		t.Errorf("expected POST not to be retried by default, got %d attempts", attempts)
+	}
+
+	policy := testRetryPolicy()
+	policy.RetryNonIdempotent = true
+	attempts, bodies = 0, nil
+	client = newTestClient(t, handler, WithRetryPolicy(policy))
+	err := client.From("users").Insert(map[string]int{"id": 1}).Execute(&rows)
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.Code != "40001" {
+		t.Errorf("expected the last serialization failure, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	for _, body := range bodies {
+		if body != `{"id":1}` {
+			t.Errorf("expected body to be replayed, got %q", body)
+		}
+	}
+}
+
+func TestRetryPolicy_ContextCanceled(t *testing.T) {
+	policy := testRetryPolicy()
+	policy.MaxBackoff = time.Minute
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var rows []map[string]interface{}
+	start := time.Now()
+	err := client.From("users").Select("*").ExecuteWithContext(ctx, &rows)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.HTTPStatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected the 429 error of the last attempt to be kept, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("expected waiting for Retry-After to stop with the context")
+	}
+}
+
+func TestRetryPolicy_RetryAfterExceedsMaxBackoff(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetryPolicy(testRetryPolicy()))
+
+	var rows []map[string]interface{}
+	start := time.Now()
+	err := client.From("users").Select("*").Execute(&rows)
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.HTTPStatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the 503 error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected no retry beyond MaxBackoff, got %d attempts", attempts)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("expected not to wait for Retry-After")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if wait, ok := retryAfter(http.Header{"Retry-After": {"3"}}); !ok || wait != 3*time.Second {
+		t.Errorf("expected 3s, got %v", wait)
+	}
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if wait, ok := retryAfter(http.Header{"Retry-After": {date}}); !ok || wait <= 0 || wait > time.Minute {
+		t.Errorf("expected up to a minute, got %v", wait)
+	}
+
+	if _, ok := retryAfter(http.Header{}); ok {
+		t.Error("expected no Retry-After")
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}
+	for i, w := range want {
+		if got := policy.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) == %v, want %v", i+1, got, w)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := policy.backoff(1); got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("expected jittered backoff within [50ms, 100ms], got %v", got)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package postgrest_go
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServerTiming holds the durations reported by PostgREST in the Server-Timing header, which is sent
+// when server-timing-enabled is set.
+type ServerTiming struct {
+	JWT         time.Duration
+	Parse       time.Duration
+	Plan        time.Duration
+	Transaction time.Duration
+	Response    time.Duration
+	// Other holds metrics other than the ones above, e.g. added by a proxy.
+	Other map[string]time.Duration
+}
+
+// Total returns the sum of all reported durations.
+func (st ServerTiming) Total() time.Duration {
+	total := st.JWT + st.Parse + st.Plan + st.Transaction + st.Response
+	for _, d := range st.Other {
+		total += d
+	}
+	return total
+}
+
+// IsZero reports whether no durations were reported.
+func (st ServerTiming) IsZero() bool {
+	return st.Total() == 0 && len(st.Other) == 0
+}
+
+// ParseServerTiming parses a Server-Timing header such as "jwt;dur=14.9, parse;dur=71.1".
+// Metrics without a duration and malformed entries are skipped.
+func ParseServerTiming(header string) ServerTiming {
+	var st ServerTiming
+	for _, metric := range strings.Split(header, ",") {
+		params := strings.Split(metric, ";")
+		name := strings.TrimSpace(params[0])
+		if name == "" {
+			continue
+		}
+
+		var duration time.Duration
+		var found bool
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if !strings.EqualFold(key, "dur") {
+				continue
+			}
+			ms, err := strconv.ParseFloat(strings.Trim(value, `"`), 64)
+			if err != nil {
+				break
+			}
+			duration, found = time.Duration(ms*float64(time.Millisecond)), true
+		}
+		if !found {
+			continue
+		}
+
+		switch strings.ToLower(name) {
+		case "jwt":
+			st.JWT = duration
+		case "parse":
+			st.Parse = duration
+		case "plan":
+			st.Plan = duration
+		case "transaction":
+			st.Transaction = duration
+		case "response":
+			st.Response = duration
+		default:
+			if st.Other == nil {
+				st.Other = map[string]time.Duration{}
+			}
+			st.Other[name] = duration
+		}
+	}
+	return st
+}
+
+// ServerTimingObserver can be implemented by a MetricsObserver to receive the server timings of
+// requests whose response carried a Server-Timing header.
+type ServerTimingObserver interface {
+	ObserveServerTiming(op Operation, timing ServerTiming)
+}
+
+// responseHeader returns the headers of the response of a request, which failed if err is set.
+func responseHeader(resp *http.Response, err error) http.Header {
+	if resp != nil {
+		return resp.Header
+	}
+
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.Header
+	}
+	return nil
+}
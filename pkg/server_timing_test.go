@@ -0,0 +1,69 @@
+package postgrest_go
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseServerTiming(t *testing.T) {
+	st := ParseServerTiming(`jwt;dur=14.9, parse;dur=71.1, plan;dur=109.0, transaction;dur=353.2, response;dur=4.4, cdn;desc="edge";dur=2, miss`)
+
+	want := map[string]time.Duration{
+		"jwt":         14900 * time.Microsecond,
+		"parse":       71100 * time.Microsecond,
+		"plan":        109 * time.Millisecond,
+		"transaction": 353200 * time.Microsecond,
+		"response":    4400 * time.Microsecond,
+	}
+	got := map[string]time.Duration{
+		"jwt":         st.JWT,
+		"parse":       st.Parse,
+		"plan":        st.Plan,
+		"transaction": st.Transaction,
+		"response":    st.Response,
+	}
+	for name, d := range want {
+		if got[name] != d {
+			t.Errorf("expected %s == %v, got %v", name, d, got[name])
+		}
+	}
+
+	if len(st.Other) != 1 || st.Other["cdn"] != 2*time.Millisecond {
+		t.Errorf("unexpected other metrics %v", st.Other)
+	}
+	if st.Total() != 554600*time.Microsecond {
+		t.Errorf("expected total == %v, got %v", 554600*time.Microsecond, st.Total())
+	}
+
+	if !ParseServerTiming("").IsZero() {
+		t.Error("expected empty header to parse as zero")
+	}
+}
+
+func TestServerTiming_Metrics(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server-Timing", "jwt;dur=1, transaction;dur=10")
+		w.Write([]byte(`[]`))
+	})
+
+	metrics := NewInMemoryMetrics()
+	WithMetrics(metrics)(client)
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.From("users").Select("*").ExecuteRaw()
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Close()
+
+		if resp.ServerTiming.Transaction != 10*time.Millisecond {
+			t.Errorf("expected transaction == 10ms, got %v", resp.ServerTiming.Transaction)
+		}
+	}
+
+	stats := metrics.Snapshot()[0]
+	if stats.ServerTimingSamples != 2 || stats.ServerTiming.JWT != 2*time.Millisecond || stats.ServerTiming.Transaction != 20*time.Millisecond {
+		t.Errorf("unexpected server timing stats %d %+v", stats.ServerTimingSamples, stats.ServerTiming)
+	}
+}
@@ -0,0 +1,87 @@
+package postgrest_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// TimeoutError is returned when a request ran out of time, either because its context deadline passed
+// or because the database canceled the statement after its statement timeout (57014).
+// It wraps the underlying error, which is context.DeadlineExceeded or a RequestError.
+type TimeoutError struct {
+	// StatementTimeout is the timeout requested from the server, if any.
+	StatementTimeout time.Duration
+	Err              error
+}
+
+func (e *TimeoutError) Error() string {
+	if e.StatementTimeout > 0 {
+		return fmt.Sprintf("request timed out (statement timeout %s): %s", e.StatementTimeout, e.Err)
+	}
+	return "request timed out: " + e.Err.Error()
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout reports that the error is a timeout, like net.Error.
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+// WithDeadlineTimeout sends the time left until the context deadline of each request, minus margin,
+// as the statement timeout through the Prefer header. This lets the database stop working on a request
+// the client already gave up on. The timeout is rounded down to whole seconds; requests without a deadline or
+// with less than a second left are not affected.
+func WithDeadlineTimeout(margin time.Duration) ClientOption {
+	return func(c *Client) {
+		c.deadlineTimeout = true
+		c.deadlineTimeoutMargin = margin
+	}
+}
+
+// statementTimeout returns the statement timeout to request for ctx, rounded down to whole seconds so the
+// database gives up before the client does. Less than a second left requests no timeout.
+func (c *Client) statementTimeout(ctx context.Context) (time.Duration, bool) {
+	if !c.deadlineTimeout {
+		return 0, false
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+
+	remaining := (time.Until(deadline) - c.deadlineTimeoutMargin).Truncate(time.Second)
+	if remaining < time.Second {
+		return 0, false
+	}
+	return remaining, true
+}
+
+// setStatementTimeout adds the statement timeout derived from the request's deadline to its Prefer header.
+func (c *Client) setStatementTimeout(req *http.Request) time.Duration {
+	timeout, ok := c.statementTimeout(req.Context())
+	if !ok {
+		return 0
+	}
+
+	setPreference(req.Header, "timeout", strconv.Itoa(int(timeout/time.Second)))
+	return timeout
+}
+
+// timeoutError wraps err in a TimeoutError if it was caused by a timeout.
+func timeoutError(err error, statementTimeout time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrStatementTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return &TimeoutError{StatementTimeout: statementTimeout, Err: err}
+	}
+	return err
+}
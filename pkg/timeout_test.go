@@ -0,0 +1,98 @@
+package postgrest_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithDeadlineTimeout(t *testing.T) {
+	var prefer string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		prefer = r.Header.Get("Prefer")
+		w.Write([]byte(`[]`))
+	}, WithDeadlineTimeout(time.Second))
+
+	resp, err := client.From("users").Select("*").Count().ExecuteRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Close()
+	if prefer != "count=exact" {
+		t.Errorf("expected prefer == count=exact, got %s", prefer)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err = client.From("users").Select("*").Count().ExecuteRawWithContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Close()
+	if prefer != "count=exact,timeout=3" {
+		t.Errorf("expected prefer == count=exact,timeout=3, got %s", prefer)
+	}
+
+	var rows []map[string]interface{}
+	if err := client.Rpc("slow", nil).ExecuteWithContext(ctx, &rows); err != nil {
+		t.Fatal(err)
+	}
+	if prefer != "timeout=3" {
+		t.Errorf("expected prefer == timeout=3, got %s", prefer)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 1800*time.Millisecond)
+	defer cancel()
+
+	if err := client.Rpc("slow", nil).ExecuteWithContext(ctx, &rows); err != nil {
+		t.Fatal(err)
+	}
+	if prefer != "" {
+		t.Errorf("expected no prefer for less than a second left, got %s", prefer)
+	}
+}
+
+func TestTimeoutError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rpc/slow" {
+			time.Sleep(100 * time.Millisecond)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"code":"57014","message":"canceling statement due to statement timeout"}`))
+	}, WithDeadlineTimeout(0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2500*time.Millisecond)
+	defer cancel()
+
+	var rows []map[string]interface{}
+	err := client.From("users").Select("*").ExecuteWithContext(ctx, &rows)
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected TimeoutError, got %v", err)
+	}
+	if timeoutErr.StatementTimeout != 2*time.Second {
+		t.Errorf("expected statement timeout == 2s, got %s", timeoutErr.StatementTimeout)
+	}
+	if !errors.Is(err, ErrStatementTimeout) {
+		t.Errorf("expected %v to match ErrStatementTimeout", err)
+	}
+	if !IsTimeout(err) {
+		t.Errorf("expected IsTimeout(%v) == true", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = client.Rpc("slow", nil).ExecuteWithContext(ctx, &rows)
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected TimeoutError, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v to match context.DeadlineExceeded", err)
+	}
+}
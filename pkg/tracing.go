@@ -0,0 +1,82 @@
+package postgrest_go
+
+import (
+	"context"
+	"net/http"
+)
+
+// Tracer is notified around every request sent by the client, which allows recording requests in a
+// distributed tracing system. An OpenTelemetry tracer can be adapted by starting a span in Start,
+// injecting its context with the configured propagator in Inject and ending it in End.
+type Tracer interface {
+	// Start is called before a request is sent, including each retry of a request.
+	Start(ctx context.Context, info RequestInfo) RequestSpan
+}
+
+// RequestSpan is the span of a single request started by a Tracer.
+type RequestSpan interface {
+	// Inject adds the trace context headers, such as traceparent, to the outgoing request.
+	Inject(header http.Header)
+	// End is called once the response headers were received or the request failed. The status is 0
+	// when no response was received.
+	End(status int, err error)
+}
+
+// RequestInfo describes a request passed to a Tracer.
+type RequestInfo struct {
+	Operation
+	Method string
+	URL    string
+}
+
+// WithTracer notifies tracer around every request sent by the client.
+func WithTracer(tracer Tracer) ClientOption {
+	return func(c *Client) {
+		c.Transport.tracer = tracer
+	}
+}
+
+type traceContextKey struct{}
+
+// traceContext holds W3C trace context headers.
+type traceContext struct {
+	traceParent string
+	traceState  string
+}
+
+// ContextWithTraceParent returns a context whose requests carry the given W3C traceparent and tracestate
+// headers, e.g. those received by the server handling the current request. Headers injected by a Tracer
+// take precedence.
+func ContextWithTraceParent(ctx context.Context, traceParent, traceState string) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, traceContext{traceParent: traceParent, traceState: traceState})
+}
+
+// startSpan starts the span of req and returns a copy of req carrying the trace context headers.
+func (c *PostgrestTransport) startSpan(req *http.Request, op Operation) (*http.Request, RequestSpan) {
+	ctx := req.Context()
+	tc, hasTraceContext := ctx.Value(traceContextKey{}).(traceContext)
+	if c.tracer == nil && !hasTraceContext {
+		return req, nil
+	}
+
+	// A RoundTripper must not modify the request it was given.
+	req = req.Clone(ctx)
+	if hasTraceContext && tc.traceParent != "" {
+		req.Header.Set("traceparent", tc.traceParent)
+		if tc.traceState != "" {
+			req.Header.Set("tracestate", tc.traceState)
+		}
+	}
+
+	if c.tracer == nil {
+		return req, nil
+	}
+
+	span := c.tracer.Start(ctx, RequestInfo{
+		Operation: op,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+	})
+	span.Inject(req.Header)
+	return req, span
+}
@@ -0,0 +1,81 @@
+package postgrest_go
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+type recordingTracer struct {
+	spans []*recordingSpan
+}
+
+type recordingSpan struct {
+	info   RequestInfo
+	status int
+	err    error
+	ended  bool
+}
+
+func (t *recordingTracer) Start(ctx context.Context, info RequestInfo) RequestSpan {
+	span := &recordingSpan{info: info}
+	t.spans = append(t.spans, span)
+	return span
+}
+
+func (s *recordingSpan) Inject(header http.Header) {
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+}
+
+func (s *recordingSpan) End(status int, err error) {
+	s.status, s.err, s.ended = status, err, true
+}
+
+func TestWithTracer(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("traceparent"); got != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+			t.Errorf("unexpected header traceparent %s", got)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`1`))
+	})
+
+	tracer := &recordingTracer{}
+	WithTracer(tracer)(client)
+
+	var result int
+	if err := client.Rpc("add", map[string]interface{}{"a": 1}).Execute(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if !span.ended || span.status != http.StatusCreated || span.err != nil {
+		t.Errorf("unexpected span end %+v", span)
+	}
+	if span.info.Resource != "rpc/add" || span.info.Kind != "rpc" || span.info.Method != http.MethodPost {
+		t.Errorf("unexpected span info %+v", span.info)
+	}
+}
+
+func TestContextWithTraceParent(t *testing.T) {
+	traceParent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("traceparent"); got != traceParent {
+			t.Errorf("expected header traceparent == %s, got %s", traceParent, got)
+		}
+		if got := r.Header.Get("tracestate"); got != "congo=t61rcWkgMzE" {
+			t.Errorf("expected header tracestate == %s, got %s", "congo=t61rcWkgMzE", got)
+		}
+		w.Write([]byte(`[]`))
+	})
+
+	ctx := ContextWithTraceParent(context.Background(), traceParent, "congo=t61rcWkgMzE")
+
+	var rows []map[string]interface{}
+	if err := client.From("users").Select("*").ExecuteWithContext(ctx, &rows); err != nil {
+		t.Fatal(err)
+	}
+}
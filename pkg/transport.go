@@ -1,29 +1,121 @@
 package postgrest_go
 
 import (
-	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
 type PostgrestTransport struct {
-	baseURL url.URL
-	debug   bool
+	baseURL       url.URL
+	logger        *requestLogger
+	tracer        Tracer
+	breaker       *circuitBreaker
+	requestLimits *requestLimits
 
 	Parent http.RoundTripper
 }
 
 func (c *PostgrestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	if c.debug {
-		fmt.Println("--- incoming postgrest-go req ---")
-		fmt.Printf("%s %s\n", req.Method, req.URL.String())
-		for key, headerValues := range req.Header {
-			for _, val := range headerValues {
-				fmt.Printf("%s: %s\n", key, val)
-			}
+	op := c.operation(req)
+	req, span := c.startSpan(req, op)
+
+	resp, err := c.limitedRoundTrip(req, op)
+	if span != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
 		}
-		fmt.Println("---------------------------------")
+		span.End(status, err)
+	}
+	return resp, err
+}
+
+// limitedRoundTrip sends the request once the rate and concurrency limits allow it, and logs it.
+func (c *PostgrestTransport) limitedRoundTrip(req *http.Request, op Operation) (*http.Response, error) {
+	release, err := c.requestLimits.acquire(req, op)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqBody string
+	if c.logger != nil {
+		reqBody = c.logger.requestBody(req)
+	}
+
+	start := time.Now()
+	resp, err := c.roundTrip(req)
+	if c.logger != nil {
+		c.logger.log(req, op, resp, err, time.Since(start), reqBody)
+	}
+	if err != nil {
+		release()
+		return nil, err
 	}
 
-	return c.Parent.RoundTrip(req)
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// roundTrip sends the request through the circuit breaker, if any.
+func (c *PostgrestTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	if c.breaker == nil {
+		return c.Parent.RoundTrip(req)
+	}
+
+	generation, err := c.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Parent.RoundTrip(req)
+	c.breaker.done(generation, outcome(req, resp, err))
+	return resp, err
+}
+
+// Operation describes what a request does.
+type Operation struct {
+	// Resource is the table or view the request targets, or the function prefixed with "rpc/".
+	Resource string
+	// Kind is one of "select", "insert", "update", "upsert", "delete" or "rpc".
+	Kind string
+}
+
+// operation returns the Operation performed by req.
+func (c *PostgrestTransport) operation(req *http.Request) Operation {
+	resource := strings.TrimPrefix(req.URL.Path, c.baseURL.Path)
+	resource = strings.Trim(resource, "/")
+
+	if strings.HasPrefix(resource, "rpc/") {
+		return Operation{Resource: resource, Kind: "rpc"}
+	}
+
+	var kind string
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		kind = "select"
+	case http.MethodPost:
+		kind = "insert"
+		if strings.Contains(req.Header.Get("Prefer"), "resolution=") {
+			kind = "upsert"
+		}
+	case http.MethodPut:
+		kind = "upsert"
+	case http.MethodPatch:
+		kind = "update"
+	case http.MethodDelete:
+		kind = "delete"
+	default:
+		kind = strings.ToLower(req.Method)
+	}
+	return Operation{Resource: resource, Kind: kind}
+}
+
+// CircuitState returns the state of the circuit breaker, which is always closed when none is configured.
+func (c *PostgrestTransport) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.State()
 }
@@ -0,0 +1,114 @@
+package postgrest_go
+
+import "context"
+
+// TypedTable is a RequestBuilder whose rows are decoded into T.
+type TypedTable[T any] struct {
+	*RequestBuilder
+}
+
+// From starts building a request for table whose rows are decoded into T.
+func From[T any](c *Client, table string) *TypedTable[T] {
+	return &TypedTable[T]{c.From(table)}
+}
+
+// Select starts building a SELECT request with the specified columns.
+func (t *TypedTable[T]) Select(columns ...string) *TypedSelect[T] {
+	return &TypedSelect[T]{t.RequestBuilder.Select(columns...)}
+}
+
+// Insert starts building an INSERT request for rows.
+func (t *TypedTable[T]) Insert(rows ...T) *TypedMutation[T] {
+	return newTypedMutation[T](t.RequestBuilder.Insert(rows))
+}
+
+// Upsert starts building an UPSERT request for rows.
+func (t *TypedTable[T]) Upsert(rows ...T) *TypedMutation[T] {
+	return newTypedMutation[T](t.RequestBuilder.Upsert(rows))
+}
+
+// Update starts building an UPDATE request with the provided JSON data.
+// It accepts any value so that only a subset of the columns of T can be set.
+func (t *TypedTable[T]) Update(json interface{}) *TypedMutation[T] {
+	return &TypedMutation[T]{t.RequestBuilder.Update(json)}
+}
+
+// Delete starts building a DELETE request returning the deleted rows.
+func (t *TypedTable[T]) Delete() *TypedMutation[T] {
+	t.header.Set("Prefer", "return=representation")
+	return &TypedMutation[T]{t.RequestBuilder.Delete()}
+}
+
+// TypedSelect is a SelectRequestBuilder whose rows are decoded into T.
+// Filters and modifiers of the embedded builder apply in place, so they can be called without
+// reassigning the result:
+//
+//	q := From[User](client, "users").Select("*")
+//	q.Eq("active", "true")
+//	users, err := q.All(ctx)
+type TypedSelect[T any] struct {
+	*SelectRequestBuilder
+}
+
+// All executes the request and returns all matching rows.
+func (q *TypedSelect[T]) All(ctx context.Context) ([]T, error) {
+	var rows []T
+	if err := q.ExecuteWithContext(ctx, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// One executes the request for exactly one row. The returned error matches ErrNotFound when no rows match.
+func (q *TypedSelect[T]) One(ctx context.Context) (T, error) {
+	var row T
+	err := q.Single().ExecuteWithContext(ctx, &row)
+	return row, err
+}
+
+// MaybeOne executes the request for at most one row. It returns nil when no rows match.
+func (q *TypedSelect[T]) MaybeOne(ctx context.Context) (*T, error) {
+	var row T
+	found, err := q.ExecuteMaybeWithContext(ctx, &row)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// TypedMutation is a mutation request whose returned rows are decoded into T.
+type TypedMutation[T any] struct {
+	*FilterRequestBuilder
+}
+
+func newTypedMutation[T any](q *QueryRequestBuilder) *TypedMutation[T] {
+	return &TypedMutation[T]{&FilterRequestBuilder{QueryRequestBuilder: *q}}
+}
+
+// All executes the request and returns the affected rows.
+func (m *TypedMutation[T]) All(ctx context.Context) ([]T, error) {
+	var rows []T
+	if err := m.ExecuteWithContext(ctx, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// TypedRpc is a function call whose result is decoded into Result.
+type TypedRpc[Result any] struct {
+	*RpcRequestBuilder
+}
+
+// Rpc starts building a call of function f with args, which are sent as a JSON object.
+func Rpc[Args, Result any](c *Client, f string, args Args) *TypedRpc[Result] {
+	r := c.Rpc(f, nil)
+	r.params = args
+	return &TypedRpc[Result]{r}
+}
+
+// Call calls the function and returns its result.
+func (r *TypedRpc[Result]) Call(ctx context.Context) (Result, error) {
+	var result Result
+	err := r.ExecuteWithContext(ctx, &result)
+	return result, err
+}
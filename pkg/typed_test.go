@@ -0,0 +1,102 @@
+package postgrest_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type typedUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestTypedSelect_All(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("name"); got != "eq.alice" {
+			t.Errorf("expected param name == %s, got %s", "eq.alice", got)
+		}
+		w.Write([]byte(`[{"id":1,"name":"alice"}]`))
+	})
+
+	q := From[typedUser](client, "users").Select("id", "name")
+	q.Eq("name", "alice")
+
+	users, err := q.All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0] != (typedUser{ID: 1, Name: "alice"}) {
+		t.Errorf("unexpected users %+v", users)
+	}
+}
+
+func TestTypedSelect_One(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != MediaTypeObjectJSON {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.WriteHeader(http.StatusNotAcceptable)
+		w.Write([]byte(`{"code":"PGRST116","details":"The result contains 0 rows","message":"JSON object requested, multiple (or no) rows returned"}`))
+	})
+
+	_, err := From[typedUser](client, "users").Select("*").One(context.Background())
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	user, err := From[typedUser](client, "users").Select("*").MaybeOne(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != nil {
+		t.Errorf("expected nil user, got %+v", user)
+	}
+}
+
+func TestTypedMutation_Insert(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var rows []typedUser
+		if err := json.NewDecoder(r.Body).Decode(&rows); err != nil {
+			t.Fatal(err)
+		}
+		for i := range rows {
+			rows[i].ID = i + 1
+		}
+		json.NewEncoder(w).Encode(rows)
+	})
+
+	users, err := From[typedUser](client, "users").Insert(typedUser{Name: "alice"}, typedUser{Name: "bob"}).All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[1].ID != 2 || users[1].Name != "bob" {
+		t.Errorf("unexpected users %+v", users)
+	}
+}
+
+func TestTypedRpc_Call(t *testing.T) {
+	type addArgs struct {
+		A int `json:"a"`
+		B int `json:"b"`
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var args addArgs
+		if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+			t.Fatal(err)
+		}
+		json.NewEncoder(w).Encode(args.A + args.B)
+	})
+
+	sum, err := Rpc[addArgs, int](client, "add", addArgs{A: 2, B: 3}).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum != 5 {
+		t.Errorf("expected sum == %d, got %d", 5, sum)
+	}
+}
@@ -0,0 +1,145 @@
+package postgrest_go
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNoChanges is returned when executing a partial update that has no columns to change.
+var ErrNoChanges = errors.New("no columns changed")
+
+// UpdateChanged starts building an UPDATE request that only sets the columns whose values differ between
+// original and modified, which must be structs or maps of the same type. Struct fields map to columns like
+// in SelectColumns; embedded resources are not sent. Executing the request returns ErrNoChanges when
+// nothing changed.
+func (b *RequestBuilder) UpdateChanged(original, modified interface{}) *FilterRequestBuilder {
+	changes, err := diffColumns(original, modified)
+	u := b.Update(changes)
+	if err == nil && len(changes) == 0 {
+		err = ErrNoChanges
+	}
+	u.err = err
+	return u
+}
+
+// UpdateColumns starts building an UPDATE request that only sets the given columns to their values in v,
+// which must be a struct or a map.
+func (b *RequestBuilder) UpdateColumns(v interface{}, columns ...string) *FilterRequestBuilder {
+	values, err := columnValues(reflect.ValueOf(v))
+
+	changes := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		if err != nil {
+			break
+		}
+
+		value, ok := values[column]
+		if !ok {
+			err = fmt.Errorf("unknown column %s", column)
+			break
+		}
+		changes[column] = value
+	}
+
+	u := b.Update(changes)
+	if err == nil && len(changes) == 0 {
+		err = ErrNoChanges
+	}
+	u.err = err
+	return u
+}
+
+// diffColumns returns the columns of modified whose values differ from original.
+func diffColumns(original, modified interface{}) (map[string]interface{}, error) {
+	if reflect.TypeOf(original) != reflect.TypeOf(modified) {
+		return nil, fmt.Errorf("cannot diff %T against %T", modified, original)
+	}
+
+	before, err := columnValues(reflect.ValueOf(original))
+	if err != nil {
+		return nil, err
+	}
+	after, err := columnValues(reflect.ValueOf(modified))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]interface{}{}
+	for column, value := range after {
+		if old, ok := before[column]; !ok || !reflect.DeepEqual(old, value) {
+			changes[column] = value
+		}
+	}
+	// Keys removed from a map are cleared.
+	for column := range before {
+		if _, ok := after[column]; !ok {
+			changes[column] = nil
+		}
+	}
+	return changes, nil
+}
+
+// columnValues returns the values of the columns of a struct or map, keyed by column name.
+func columnValues(v reflect.Value) (map[string]interface{}, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, errors.New("cannot read columns of nil")
+		}
+		v = v.Elem()
+	}
+
+	values := map[string]interface{}{}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("cannot read columns of %s, expected string keys", v.Type())
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			values[iter.Key().String()] = iter.Value().Interface()
+		}
+	case reflect.Struct:
+		if err := structValues(v, values); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("cannot read columns of %s, expected a map or struct", v.Type())
+	}
+	return values, nil
+}
+
+func structValues(v reflect.Value, values map[string]interface{}) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := parseFieldTag(field)
+		if tag.skip || field.Name == "_" {
+			continue
+		}
+
+		if field.Anonymous && !tagged(field) && elemStructType(field.Type).Kind() == reflect.Struct {
+			embedded := v.Field(i)
+			if embedded.Kind() == reflect.Pointer {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if err := structValues(embedded, values); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() || (!tag.scalar && isEmbeddable(field.Type)) {
+			continue
+		}
+
+		column := tag.name
+		if tag.column != "" {
+			column = tag.column
+		}
+		values[column] = v.Field(i).Interface()
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package postgrest_go
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+type diffProject struct {
+	ID    int           `json:"id"`
+	Title string        `json:"title,omitempty"`
+	Tags  []string      `json:"tags"`
+	Owner *columnsOwner `json:"owner"`
+}
+
+func TestRequestBuilder_UpdateChanged(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if len(body) != 1 || body["title"] != "" {
+			t.Errorf("expected only the cleared title to be sent, got %v", body)
+		}
+		w.Write([]byte(`[]`))
+	})
+
+	original := diffProject{ID: 1, Title: "draft", Tags: []string{"a"}, Owner: &columnsOwner{ID: 1}}
+	modified := original
+	modified.Title = ""
+	modified.Owner = &columnsOwner{ID: 2}
+
+	var rows []diffProject
+	err := client.From("projects").UpdateChanged(original, modified).Eq("id", "1").Execute(&rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRequestBuilder_UpdateChangedNoChanges(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request to be sent")
+	})
+
+	project := diffProject{ID: 1, Tags: []string{"a"}}
+
+	var rows []diffProject
+	err := client.From("projects").UpdateChanged(project, project).Eq("id", "1").Execute(&rows)
+	if !errors.Is(err, ErrNoChanges) {
+		t.Errorf("expected ErrNoChanges, got %v", err)
+	}
+
+	err = client.From("projects").UpdateChanged(project, &project).Execute(&rows)
+	if err == nil || errors.Is(err, ErrNoChanges) {
+		t.Errorf("expected type mismatch error, got %v", err)
+	}
+}
+
+func TestRequestBuilder_UpdateColumns(t *testing.T) {
+	builder := RequestBuilder{
+		client: NewClient(url.URL{Scheme: "https", Host: "example.com"}),
+		header: http.Header{},
+		params: url.Values{},
+	}
+
+	project := diffProject{ID: 1, Title: "final", Tags: []string{"b"}}
+	u := builder.UpdateColumns(project, "title")
+	if u.err != nil {
+		t.Fatal(u.err)
+	}
+
+	data, err := json.Marshal(u.json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"title":"final"}` {
+		t.Errorf("unexpected body %s", data)
+	}
+
+	if u := builder.UpdateColumns(project, "missing"); u.err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
@@ -2,6 +2,8 @@ package postgrest_go
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -14,6 +16,56 @@ func SanitizeParam(param string) string {
 	return param
 }
 
+// quoteFilterValue quotes value like SanitizeParam, also escaping double quotes and backslashes within it.
+func quoteFilterValue(value string) string {
+	if !strings.ContainsAny(value, reservedChars+`"\`) {
+		return value
+	}
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return `"` + value + `"`
+}
+
+// queryValueEscaper percent-encodes the characters of a filter value that would otherwise change its meaning
+// once the query string is sent, such as a + decoded as a space.
+var queryValueEscaper = strings.NewReplacer("%", "%25", "+", "%2B", "&", "%26", "#", "%23", " ", "%20")
+
 func SanitizePatternParam(pattern string) string {
 	return SanitizeParam(strings.ReplaceAll(pattern, "%", "*"))
 }
+
+func cloneValues(values url.Values) url.Values {
+	cloned := make(url.Values, len(values))
+	for key, vals := range values {
+		cloned[key] = append([]string(nil), vals...)
+	}
+	return cloned
+}
+
+// removePreference removes the preference with the given name from the Prefer header.
+func removePreference(header http.Header, name string) {
+	var kept []string
+	for _, pref := range strings.Split(header.Get("Prefer"), ",") {
+		pref = strings.TrimSpace(pref)
+		if pref == "" || pref == name || strings.HasPrefix(pref, name+"=") {
+			continue
+		}
+		kept = append(kept, pref)
+	}
+
+	if len(kept) == 0 {
+		header.Del("Prefer")
+		return
+	}
+	header.Set("Prefer", strings.Join(kept, ","))
+}
+
+// setPreference sets the preference with the given name in the Prefer header, keeping other preferences.
+func setPreference(header http.Header, name, value string) {
+	removePreference(header, name)
+
+	pref := name + "=" + value
+	if prefer := header.Get("Prefer"); prefer != "" {
+		pref = prefer + "," + pref
+	}
+	header.Set("Prefer", pref)
+}
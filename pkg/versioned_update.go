@@ -0,0 +1,90 @@
+package postgrest_go
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// ErrConflict is matched by errors.Is when a version-checked update affected no rows.
+var ErrConflict = errors.New("row was modified concurrently")
+
+// ConflictError is returned when a version-checked update affected no rows, because the row was changed by
+// another writer since it was read, or because it doesn't exist anymore.
+type ConflictError struct {
+	Column  string
+	Version interface{}
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("row was modified concurrently: %s no longer matches %v", e.Column, e.Version)
+}
+
+// Is reports whether target is ErrConflict.
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// versionCheck is the version filter of an update, which must match at least one row.
+type versionCheck struct {
+	column  string
+	version interface{}
+}
+
+// UpdateVersioned starts building an UPDATE request that only applies to rows whose version column still
+// equals current, and sets the column to next along with the columns of json, a struct or map.
+// Executing the request returns a ConflictError when no rows were updated.
+func (b *RequestBuilder) UpdateVersioned(json interface{}, column string, current, next interface{}) *FilterRequestBuilder {
+	values, err := columnValues(reflect.ValueOf(json))
+	if err == nil {
+		values[column] = next
+	}
+
+	u := b.Update(values)
+	u.err = err
+	u.versionCheck = &versionCheck{column: column, version: current}
+
+	criteria, err := formatMatchValue(reflect.ValueOf(current))
+	if err != nil {
+		u.err = fmt.Errorf("invalid version value: %w", err)
+		return u
+	}
+	return u.Filter(column, "eq", queryValueEscaper.Replace(quoteFilterValue(criteria)))
+}
+
+// UpdateIncrementVersion is like UpdateVersioned for an integer version column, which is incremented.
+func (b *RequestBuilder) UpdateIncrementVersion(json interface{}, column string, current int64) *FilterRequestBuilder {
+	return b.UpdateVersioned(json, column, current, current+1)
+}
+
+// checkVersion returns a ConflictError if the representation in body holds no rows.
+func (v *versionCheck) checkVersion(body []byte) error {
+	var rows []json.RawMessage
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return &ConflictError{Column: v.column, Version: v.version}
+	}
+	return nil
+}
+
+// checkResponse buffers the representation in the body of resp to check it like checkVersion, leaving an
+// equivalent body in place for the caller.
+func (v *versionCheck) checkResponse(resp *http.Response) error {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err = v.checkVersion(body); err != nil {
+		return err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return nil
+}
@@ -0,0 +1,102 @@
+package postgrest_go
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRequestBuilder_UpdateIncrementVersion(t *testing.T) {
+	var response string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("version") != "eq.3" || query.Get("id") != "eq.1" {
+			t.Errorf("unexpected filters %s", r.URL.RawQuery)
+		}
+		if got := r.Header.Get("Prefer"); got != "return=representation" {
+			t.Errorf("expected header Prefer == %s, got %s", "return=representation", got)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body["version"] != float64(4) || body["title"] != "final" {
+			t.Errorf("unexpected body %v", body)
+		}
+		w.Write([]byte(response))
+	})
+
+	changes := map[string]interface{}{"title": "final"}
+
+	response = `[{"id":1,"title":"final","version":4}]`
+	var rows []map[string]interface{}
+	if err := client.From("projects").UpdateIncrementVersion(changes, "version", 3).Eq("id", "1").Execute(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("expected 1 row, got %d", len(rows))
+	}
+
+	response = `[]`
+	err := client.From("projects").UpdateIncrementVersion(changes, "version", 3).Eq("id", "1").Execute(&rows)
+
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected *ConflictError, got %v", err)
+	}
+	if conflict.Column != "version" || conflict.Version != int64(3) {
+		t.Errorf("unexpected conflict %+v", conflict)
+	}
+}
+
+func TestRequestBuilder_UpdateVersionedRaw(t *testing.T) {
+	var response string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(response))
+	})
+
+	response = `[]`
+	_, err := client.From("users").UpdateIncrementVersion(map[string]interface{}{"name": "x"}, "version", 3).ExecuteRaw()
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict from ExecuteRaw, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	_, err = client.From("users").UpdateIncrementVersion(map[string]interface{}{"name": "x"}, "version", 3).ExecuteTo(&buf)
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict from ExecuteTo, got %v", err)
+	}
+
+	response = `[{"id":1,"version":4}]`
+	buf.Reset()
+	if _, err = client.From("users").UpdateIncrementVersion(map[string]interface{}{"name": "x"}, "version", 3).ExecuteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != response {
+		t.Errorf("expected body == %s, got %s", response, buf.String())
+	}
+}
+
+func TestRequestBuilder_UpdateVersionedTimestamp(t *testing.T) {
+	current := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("", 2*60*60))
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		want := `eq."2024-01-02T03:04:05+02:00"`
+		if got := r.URL.Query().Get("updated_at"); got != want {
+			t.Errorf("expected param updated_at == %s, got %s", want, got)
+		}
+		w.Write([]byte(`[{"id":1}]`))
+	})
+
+	var rows []map[string]interface{}
+	err := client.From("projects").
+		UpdateVersioned(map[string]interface{}{"title": "final"}, "updated_at", current, current.Add(time.Minute)).
+		Eq("id", "1").
+		Execute(&rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+}